@@ -11,6 +11,10 @@ var (
 		Usage: `EVM export mode ("full" or "ext-mpt" or "mpt" or "none")`,
 		Value: "mpt",
 	}
+	CheckRepairFlag = cli.BoolFlag{
+		Name:  "check.repair",
+		Usage: "Rebuild DAG indexes of the current epoch from raw events",
+	}
 	importCommand = cli.Command{
 		Name:      "import",
 		Usage:     "Import a blockchain file",
@@ -112,6 +116,24 @@ EVM export mode is configured with --export.evm.mode.
     opera check evm
 
 Checks EVM storage roots and code hashes
+`,
+			},
+			{
+				Name:      "dag",
+				Usage:     "Check DAG storage",
+				ArgsUsage: "[<epochFrom> <epochTo>]",
+				Action:    utils.MigrateFlags(checkDag),
+				Flags: []cli.Flag{
+					DataDirFlag,
+					CheckRepairFlag,
+				},
+				Description: `
+    opera check dag
+
+Checks events hashes, presence of parents, heads and last events indexes.
+Optional first and second arguments control the first and last epoch to check,
+by default only the current epoch is checked.
+Pass --check.repair to rebuild heads and last events indexes of the current epoch.
 `,
 			},
 		},
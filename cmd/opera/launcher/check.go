@@ -2,6 +2,7 @@ package launcher
 
 import (
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
@@ -69,3 +70,51 @@ func checkEvm(ctx *cli.Context) error {
 	log.Info("EVM storage is verified", "last", prevPoint, "elapsed", common.PrettyDuration(time.Since(start)))
 	return nil
 }
+
+func checkDag(ctx *cli.Context) error {
+	cfg := makeAllConfigs(ctx)
+
+	rawProducer := integration.DBProducer(path.Join(cfg.Node.DataDir, "chaindata"), cfg.cachescale)
+	gdb, err := makeRawGossipStore(rawProducer, cfg)
+	if err != nil {
+		log.Crit("DB opening error", "datadir", cfg.Node.DataDir, "err", err)
+	}
+	defer gdb.Close()
+
+	if ctx.Bool(CheckRepairFlag.Name) {
+		if err := gdb.RepairIndexes(); err != nil {
+			return err
+		}
+		log.Info("DAG indexes are rebuilt", "epoch", gdb.GetEpoch())
+	}
+
+	from, to := gdb.GetEpoch(), gdb.GetEpoch()
+	if len(ctx.Args()) > 0 {
+		n, err := strconv.ParseUint(ctx.Args().Get(0), 10, 32)
+		if err != nil {
+			return err
+		}
+		from = idx.Epoch(n)
+	}
+	if len(ctx.Args()) > 1 {
+		n, err := strconv.ParseUint(ctx.Args().Get(1), 10, 32)
+		if err != nil {
+			return err
+		}
+		to = idx.Epoch(n)
+	}
+
+	start, reported := time.Now(), time.Now()
+	for epoch := from; epoch <= to; epoch++ {
+		if err := gdb.CheckConsistency(epoch); err != nil {
+			log.Error("DAG storage is inconsistent", "epoch", epoch, "err", err)
+			return err
+		}
+		if time.Since(reported) >= statsReportLimit {
+			log.Info("Checking DAG storage", "last", epoch, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	log.Info("DAG storage is verified", "from", from, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
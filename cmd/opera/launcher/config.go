@@ -73,7 +73,7 @@ var (
 	// GenesisFlag specifies network genesis configuration
 	GenesisFlag = cli.StringFlag{
 		Name:  "genesis",
-		Usage: "'path to genesis file' - sets the network genesis configuration.",
+		Usage: "'path to genesis file' - sets the network genesis configuration. Files with .json extension are treated as a custom network specification.",
 	}
 	ExperimentalGenesisFlag = cli.BoolFlag{
 		Name:  "genesis.allowExperimental",
@@ -91,6 +91,17 @@ var (
 		Value: gossip.DefaultConfig(cachescale.Identity).RPCTxFeeCap,
 	}
 
+	RPCGlobalEVMTimeoutFlag = cli.DurationFlag{
+		Name:  "rpc.evmtimeout",
+		Usage: "Sets a timeout used for eth_call (0=infinite)",
+		Value: gossip.DefaultConfig(cachescale.Identity).RPCEVMTimeout,
+	}
+	RPCGlobalMaxLogsFlag = cli.IntFlag{
+		Name:  "rpc.maxlogs",
+		Usage: "Sets a limit of logs returned by eth_getLogs, searches with more logs fail (0=infinite)",
+		Value: gossip.DefaultConfig(cachescale.Identity).FilterAPI.MaxLogs,
+	}
+
 	SyncModeFlag = cli.StringFlag{
 		Name:  "syncmode",
 		Usage: `Blockchain sync mode ("full" or "snap")`,
@@ -185,6 +196,22 @@ func mayGetGenesisStore(ctx *cli.Context) *genesisstore.Store {
 	case ctx.GlobalIsSet(GenesisFlag.Name):
 		genesisPath := ctx.GlobalString(GenesisFlag.Name)
 
+		if strings.HasSuffix(genesisPath, ".json") {
+			if !ctx.GlobalBool(ExperimentalGenesisFlag.Name) {
+				utils.Fatalf("Custom network genesis is experimental. Enable experimental genesis with --genesis.allowExperimental")
+			}
+			spec, err := makefakegenesis.LoadGenesisJson(genesisPath)
+			if err != nil {
+				utils.Fatalf("Failed to read genesis JSON: %v", err)
+			}
+			genesisStore, err := makefakegenesis.ApplyGenesisJson(spec)
+			if err != nil {
+				utils.Fatalf("Failed to build genesis: %v", err)
+			}
+			log.Info("Built custom network genesis", "name", spec.Rules.Name, "id", spec.Rules.NetworkID, "genesis", genesisStore.Genesis().GenesisID.String())
+			return genesisStore
+		}
+
 		f, err := os.Open(genesisPath)
 		if err != nil {
 			utils.Fatalf("Failed to open genesis file: %v", err)
@@ -306,12 +333,27 @@ func gossipConfigWithFlags(ctx *cli.Context, src gossip.Config) (gossip.Config,
 	if ctx.GlobalIsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.GlobalFloat64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.GlobalIsSet(RPCGlobalEVMTimeoutFlag.Name) {
+		cfg.RPCEVMTimeout = ctx.GlobalDuration(RPCGlobalEVMTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCGlobalMaxLogsFlag.Name) {
+		cfg.FilterAPI.MaxLogs = ctx.GlobalInt(RPCGlobalMaxLogsFlag.Name)
+	}
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
 		if syncmode := ctx.GlobalString(SyncModeFlag.Name); syncmode != "full" && syncmode != "snap" {
 			utils.Fatalf("--%s must be either 'full' or 'snap'", SyncModeFlag.Name)
 		}
 		cfg.AllowSnapsync = ctx.GlobalString(SyncModeFlag.Name) == "snap"
 	}
+	if ctx.GlobalIsSet(utils.DNSDiscoveryFlag.Name) {
+		urls := ctx.GlobalString(utils.DNSDiscoveryFlag.Name)
+		if urls == "" {
+			cfg.OperaDiscoveryURLs = []string{}
+		} else {
+			cfg.OperaDiscoveryURLs = utils.SplitAndTrim(urls)
+		}
+		cfg.SnapDiscoveryURLs = cfg.OperaDiscoveryURLs
+	}
 
 	return cfg, nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/status-im/keycard-go/hexutils"
@@ -85,10 +86,13 @@ func exportEvents(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	err = exportTo(writer, gdb, from, to)
+	// hash the events to let user verify the file after an out-of-band distribution
+	hasher := crypto.NewKeccakState()
+	err = exportTo(io.MultiWriter(writer, hasher), gdb, from, to)
 	if err != nil {
 		utils.Fatalf("Export error: %v\n", err)
 	}
+	log.Info("Exported events hash", "file", fn, "hash", common.BytesToHash(hasher.Sum(nil)))
 
 	return nil
 }
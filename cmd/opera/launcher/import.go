@@ -18,6 +18,7 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/status-im/keycard-go/hexutils"
@@ -177,7 +178,9 @@ func importEventsFile(srv *gossip.Service, fn string) error {
 		return err
 	}
 
-	stream := rlp.NewStream(reader, 0)
+	// hash the events to let user verify the file against a published checksum
+	hasher := crypto.NewKeccakState()
+	stream := rlp.NewStream(io.TeeReader(reader, hasher), 0)
 
 	start := time.Now()
 	last := hash.Event{}
@@ -238,7 +241,7 @@ func importEventsFile(srv *gossip.Service, fn string) error {
 		events++
 	}
 	srv.WaitBlockEnd()
-	log.Info("Events import is finished", "file", fn, "last", last.String(), "imported", events, "txs", txs, "elapsed", common.PrettyDuration(time.Since(start)))
+	log.Info("Events import is finished", "file", fn, "last", last.String(), "imported", events, "txs", txs, "hash", common.BytesToHash(hasher.Sum(nil)), "elapsed", common.PrettyDuration(time.Since(start)))
 
 	return nil
 }
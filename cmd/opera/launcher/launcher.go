@@ -86,6 +86,7 @@ func initFlags() {
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV5Flag,
+		utils.DNSDiscoveryFlag,
 		utils.NetrestrictFlag,
 		utils.NodeKeyFileFlag,
 		utils.NodeKeyHexFlag,
@@ -118,7 +119,9 @@ func initFlags() {
 		configFileFlag,
 		validatorIDFlag,
 		validatorPubkeyFlag,
+		validatorNextPubkeyFlag,
 		validatorPasswordFlag,
+		validatorAutodiscoverFlag,
 		SyncModeFlag,
 	}
 	legacyRpcFlags = []cli.Flag{
@@ -152,6 +155,8 @@ func initFlags() {
 		utils.IPCPathFlag,
 		RPCGlobalGasCapFlag,
 		RPCGlobalTxFeeCapFlag,
+		RPCGlobalEVMTimeoutFlag,
+		RPCGlobalMaxLogsFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -315,6 +320,17 @@ func makeNode(ctx *cli.Context, cfg *config, genesisStore *genesisstore.Store) (
 	stack := makeConfigNode(ctx, &cfg.Node)
 
 	valKeystore := valkeystore.NewDefaultFileKeystore(path.Join(getValKeystoreDir(cfg.Node), "validator"))
+	if cfg.Emitter.Validator.ID == 0 && ctx.GlobalBool(validatorAutodiscoverFlag.Name) && gdb.HasBlockEpochState() {
+		id, pubkey := findKeystoreValidator(gdb.GetEpochState().ValidatorProfiles, valKeystore)
+		if id != 0 {
+			cfg.Emitter.Validator.ID = id
+			cfg.Emitter.Validator.PubKey = pubkey
+			autodiscoveredValidatorGauge.Update(int64(id))
+			log.Info("Validator is found in keystore", "id", id, "pubkey", pubkey.String())
+		} else {
+			log.Warn("No current validator keys are found in keystore, events won't be emitted")
+		}
+	}
 	valPubkey := cfg.Emitter.Validator.PubKey
 	if key := getFakeValidatorKey(ctx); key != nil && cfg.Emitter.Validator.ID != 0 {
 		addFakeValidatorKey(ctx, key, valPubkey, valKeystore)
@@ -329,6 +345,12 @@ func makeNode(ctx *cli.Context, cfg *config, genesisStore *genesisstore.Store) (
 			utils.Fatalf("Failed to unlock validator key: %v", err)
 		}
 	}
+	if nextPubkey := cfg.Emitter.Validator.NextPubKey; !nextPubkey.Empty() {
+		err := unlockValidatorKey(ctx, nextPubkey, valKeystore)
+		if err != nil {
+			utils.Fatalf("Failed to unlock next validator key: %v", err)
+		}
+	}
 	signer := valkeystore.NewSigner(valKeystore)
 
 	// Create and register a gossip network service.
@@ -1,6 +1,9 @@
 package launcher
 
 import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/pkg/errors"
 	cli "gopkg.in/urfave/cli.v1"
 
@@ -8,7 +11,9 @@ import (
 
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
 	"github.com/Fantom-foundation/go-opera/integration/makefakegenesis"
+	"github.com/Fantom-foundation/go-opera/inter/drivertype"
 	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+	"github.com/Fantom-foundation/go-opera/valkeystore"
 )
 
 var validatorIDFlag = cli.UintFlag{
@@ -23,12 +28,25 @@ var validatorPubkeyFlag = cli.StringFlag{
 	Value: "",
 }
 
+var validatorNextPubkeyFlag = cli.StringFlag{
+	Name:  "validator.nextpubkey",
+	Usage: "New public key of a validator, which replaces --validator.pubkey since the epoch where it's registered in SFC",
+	Value: "",
+}
+
 var validatorPasswordFlag = cli.StringFlag{
 	Name:  "validator.password",
 	Usage: "Password to unlock validator private key",
 	Value: "",
 }
 
+var validatorAutodiscoverFlag = cli.BoolFlag{
+	Name:  "validator.autodiscover",
+	Usage: "Create events from a current validator whose key is found in the keystore, if validator ID isn't specified",
+}
+
+var autodiscoveredValidatorGauge = metrics.GetOrRegisterGauge("opera/validator/autodiscovered", nil)
+
 // setValidatorID retrieves the validator ID either from the directly specified
 // command line flags or from the keystore if CLI indexed.
 func setValidator(ctx *cli.Context, cfg *emitter.Config) error {
@@ -60,8 +78,34 @@ func setValidator(ctx *cli.Context, cfg *emitter.Config) error {
 		cfg.Validator.PubKey = pk
 	}
 
+	if ctx.GlobalIsSet(validatorNextPubkeyFlag.Name) {
+		pk, err := validatorpk.FromString(ctx.GlobalString(validatorNextPubkeyFlag.Name))
+		if err != nil {
+			return err
+		}
+		cfg.Validator.NextPubKey = pk
+	}
+
 	if cfg.Validator.ID != 0 && cfg.Validator.PubKey.Empty() {
 		return errors.New("validator public key is not set")
 	}
 	return nil
 }
+
+// findKeystoreValidator returns the lowest ID of validators whose keys are present in the keystore.
+func findKeystoreValidator(profiles map[idx.ValidatorID]drivertype.Validator, valKeystore valkeystore.RawKeystoreI) (idx.ValidatorID, validatorpk.PubKey) {
+	ids := make([]idx.ValidatorID, 0, len(profiles))
+	for id := range profiles {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		pubkey := profiles[id].PubKey
+		if !pubkey.Empty() && valKeystore.Has(pubkey) {
+			return id, pubkey
+		}
+	}
+	return 0, validatorpk.PubKey{}
+}
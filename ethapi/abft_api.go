@@ -6,6 +6,9 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Fantom-foundation/go-opera/eventcheck/gaspowercheck"
+	"github.com/Fantom-foundation/go-opera/opera"
 )
 
 // PublicAbftAPI provides an API to access consensus related information.
@@ -53,6 +56,30 @@ func (s *PublicAbftAPI) GetDowntime(ctx context.Context, validatorID hexutil.Uin
 	}, nil
 }
 
+// GetValidatorLiveness returns validator's last event of the current epoch along with its downtime.
+func (s *PublicAbftAPI) GetValidatorLiveness(ctx context.Context, validatorID hexutil.Uint) (map[string]interface{}, error) {
+	e, err := s.b.GetLastEvent(ctx, idx.ValidatorID(validatorID))
+	if err != nil {
+		return nil, err
+	}
+	blocks, period, err := s.b.GetDowntime(ctx, idx.ValidatorID(validatorID))
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]interface{}{
+		"lastEvent":     nil,
+		"offlineBlocks": hexutil.Uint64(blocks),
+		"offlineTime":   hexutil.Uint64(period),
+	}
+	if e != nil {
+		res["lastEvent"] = hexutil.Bytes(e.ID().Bytes())
+		res["epoch"] = hexutil.Uint64(e.Epoch())
+		res["seq"] = hexutil.Uint64(e.Seq())
+		res["claimedTime"] = hexutil.Uint64(e.CreationTime())
+	}
+	return res, nil
+}
+
 // GetEpochUptime returns validator's epoch uptime in nanoseconds.
 func (s *PublicAbftAPI) GetEpochUptime(ctx context.Context, validatorID hexutil.Uint) (hexutil.Uint64, error) {
 	v, err := s.b.GetUptime(ctx, idx.ValidatorID(validatorID))
@@ -73,3 +100,40 @@ func (s *PublicAbftAPI) GetOriginatedEpochFee(ctx context.Context, validatorID h
 	}
 	return (*hexutil.Big)(v), nil
 }
+
+// GetValidatorGasPower returns validator's gas power allocation in the epoch, which is proportional to its stake.
+// perSec is the allocation per second, max is the maximum gas power which may be accumulated,
+// startup is the gas power allocated when validator emits its first event in the epoch.
+// * When epoch is -2 the allocation for latest epoch is returned.
+// * When epoch is -1 the allocation for latest sealed epoch is returned.
+func (s *PublicAbftAPI) GetValidatorGasPower(ctx context.Context, validatorID hexutil.Uint, epoch rpc.BlockNumber) (map[string]interface{}, error) {
+	_, es, err := s.b.GetEpochBlockState(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	vid := idx.ValidatorID(validatorID)
+	if es == nil || !es.Validators.Exists(vid) {
+		return nil, nil
+	}
+	economy := es.Rules.Economy
+	allocation := func(rules opera.GasPowerRules) map[string]interface{} {
+		perSec, maxGasPower, startup := gaspowercheck.CalcValidatorGasPowerPerSec(vid, es.Validators, gaspowercheck.Config{
+			AllocPerSec:        rules.AllocPerSec,
+			MaxAllocPeriod:     rules.MaxAllocPeriod,
+			MinEnsuredAlloc:    economy.Gas.MaxEventGas,
+			StartupAllocPeriod: rules.StartupAllocPeriod,
+			MinStartupGas:      rules.MinStartupGas,
+		})
+		return map[string]interface{}{
+			"perSec":  hexutil.Uint64(perSec),
+			"max":     hexutil.Uint64(maxGasPower),
+			"startup": hexutil.Uint64(startup),
+		}
+	}
+	return map[string]interface{}{
+		"epoch":     hexutil.Uint64(es.Epoch),
+		"weight":    hexutil.Uint64(es.Validators.Get(vid)),
+		"shortTerm": allocation(economy.ShortGasPower),
+		"longTerm":  allocation(economy.LongGasPower),
+	}, nil
+}
@@ -203,6 +203,17 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*RPCTransac
 	return content
 }
 
+// PackedBy returns IDs of validators which have packed the transaction into their events.
+// The events aren't necessarily confirmed yet. Only events observed since the node start are taken into account.
+func (s *PublicTxPoolAPI) PackedBy(hash common.Hash) []hexutil.Uint {
+	creators := s.b.GetTxPackers(hash)
+	res := make([]hexutil.Uint, len(creators))
+	for i, creator := range creators {
+		res[i] = hexutil.Uint(creator)
+	}
+	return res
+}
+
 // ContentFrom returns the transactions contained within the transaction pool.
 func (s *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCTransaction {
 	content := make(map[string]map[string]*RPCTransaction, 2)
@@ -1039,7 +1050,7 @@ func (e *revertError) ErrorData() interface{} {
 // Note, this function doesn't make and changes in the state/blockchain and is
 // useful to execute and retrieve values.
 func (s *PublicBlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride) (hexutil.Bytes, error) {
-	result, err := DoCall(ctx, s.b, args, blockNrOrHash, overrides, 5*time.Second, s.b.RPCGasCap())
+	result, err := DoCall(ctx, s.b, args, blockNrOrHash, overrides, s.b.RPCEVMTimeout(), s.b.RPCGasCap())
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ package ethapi
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
@@ -46,6 +47,43 @@ type PeerProgress struct {
 	CurrentBlockTime inter.Timestamp
 	HighestBlock     idx.Block
 	HighestEpoch     idx.Epoch
+	SyncStage        string
+	BlocksPerSecond  float64
+}
+
+// EventsLatency is a summary of the time between events connection and their confirmation in a block
+type EventsLatency struct {
+	Confirmed uint64
+	Last      time.Duration
+	Mean      time.Duration
+	Max       time.Duration
+}
+
+// ValidatorEventsStats is statistics of validator's events within an epoch
+type ValidatorEventsStats struct {
+	Validator idx.ValidatorID
+	Events    idx.Event
+	// GasPowerUsed is the gas power consumed by the events
+	GasPowerUsed uint64
+	// GasUsed is the gas used by the executed txs, which were originated in the events
+	GasUsed uint64
+	Size    uint64
+}
+
+// EpochStats is statistics of an epoch's events, Sealed is zero if the epoch isn't sealed yet
+type EpochStats struct {
+	Epoch      idx.Epoch
+	Validators []ValidatorEventsStats
+	MaxFrame   idx.Frame
+	Start      inter.Timestamp
+	Sealed     inter.Timestamp
+}
+
+// EventNotify is a notification about a connected event, or about confirmation of an event
+type EventNotify struct {
+	Event inter.EventI
+	// Atropos of the block which has confirmed the event, nil if the event isn't confirmed yet
+	Atropos *hash.Event
 }
 
 // Backend interface provides the common API services (that are provided by
@@ -58,9 +96,10 @@ type Backend interface {
 	ChainDb() ethdb.Database
 	AccountManager() *accounts.Manager
 	ExtRPCEnabled() bool
-	RPCGasCap() uint64        // global gas cap for eth_call over rpc: DoS protection
-	RPCTxFeeCap() float64     // global tx fee cap for all transaction related APIs
-	UnprotectedAllowed() bool // allows only for EIP155 transactions.
+	RPCGasCap() uint64            // global gas cap for eth_call over rpc: DoS protection
+	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
+	RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
+	UnprotectedAllowed() bool     // allows only for EIP155 transactions.
 	CalcBlockExtApi() bool
 
 	// Blockchain API
@@ -81,25 +120,36 @@ type Backend interface {
 	GetTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, uint64, uint64, error)
 	GetPoolTransactions() (types.Transactions, error)
 	GetPoolTransaction(txHash common.Hash) *types.Transaction
+	GetTxPackers(txHash common.Hash) []idx.ValidatorID
 	GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error)
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
 	TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions)
 	SubscribeNewTxsNotify(chan<- evmcore.NewTxsNotify) notify.Subscription
+	SubscribeDroppedTxsNotify(chan<- evmcore.DroppedTxsNotify) notify.Subscription
 
 	ChainConfig() *params.ChainConfig
 	CurrentBlock() *evmcore.EvmBlock
+	SubscribeNewBlockNotify(ch chan<- evmcore.ChainHeadNotify) notify.Subscription
 
 	// Lachesis DAG API
 	GetEventPayload(ctx context.Context, shortEventID string) (*inter.EventPayload, error)
 	GetEvent(ctx context.Context, shortEventID string) (*inter.Event, error)
 	GetHeads(ctx context.Context, epoch rpc.BlockNumber) (hash.Events, error)
+	GetEventByTransaction(ctx context.Context, txHash common.Hash) (*inter.Event, error)
+	GetBlockEvents(ctx context.Context, number rpc.BlockNumber) (*inter.Block, error)
+	GetEventsByCreator(ctx context.Context, epoch rpc.BlockNumber, creator idx.ValidatorID, fromSeq idx.Event, limit int) (hash.Events, idx.Event, error)
 	CurrentEpoch(ctx context.Context) idx.Epoch
 	SealedEpochTiming(ctx context.Context) (start inter.Timestamp, end inter.Timestamp)
+	SubscribeNewEventNotify(ch chan<- EventNotify) notify.Subscription
+	SubscribeNewEpochNotify(ch chan<- idx.Epoch) notify.Subscription
+	EventsLatency() EventsLatency
+	GetEpochStats(ctx context.Context, epoch rpc.BlockNumber) (*EpochStats, error)
 
 	// Lachesis aBFT API
 	GetEpochBlockState(ctx context.Context, epoch rpc.BlockNumber) (*iblockproc.BlockState, *iblockproc.EpochState, error)
 	GetDowntime(ctx context.Context, vid idx.ValidatorID) (idx.Block, inter.Timestamp, error)
+	GetLastEvent(ctx context.Context, vid idx.ValidatorID) (*inter.Event, error)
 	GetUptime(ctx context.Context, vid idx.ValidatorID) (*big.Int, error)
 	GetOriginatedFee(ctx context.Context, vid idx.ValidatorID) (*big.Int, error)
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -48,6 +50,215 @@ func (s *PublicDAGChainAPI) GetEventPayload(ctx context.Context, shortEventID st
 	return inter.RPCMarshalEventPayload(event, inclTx, false)
 }
 
+// GetEventByTransaction returns the Lachesis event header which carried the transaction.
+// Returns nil for pre-included transactions (e.g. internal ones) which aren't carried by events.
+func (s *PublicDAGChainAPI) GetEventByTransaction(ctx context.Context, txHash common.Hash) (map[string]interface{}, error) {
+	header, err := s.b.GetEventByTransaction(ctx, txHash)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return inter.RPCMarshalEvent(header), nil
+}
+
+// GetBlockOrdering returns the Atropos and the events whose txs are executed in a finalized block, in the order of their execution.
+// Events are ordered by ID, i.e. by epoch, then by Lamport time, then by event hash.
+// Confirmed events without txs, and the events spilled due to the block gas limit, aren't listed.
+// Headers of pruned events are omitted, only their IDs are returned.
+func (s *PublicDAGChainAPI) GetBlockOrdering(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
+	block, err := s.b.GetBlockEvents(ctx, number)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	events := make([]map[string]interface{}, len(block.Events))
+	for i, id := range block.Events {
+		events[i] = map[string]interface{}{
+			"id": hexutil.Bytes(id.Bytes()),
+		}
+		e, err := s.b.GetEvent(ctx, id.Hex())
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			events[i]["creator"] = hexutil.Uint64(e.Creator())
+			events[i]["seq"] = hexutil.Uint64(e.Seq())
+			events[i]["frame"] = hexutil.Uint64(e.Frame())
+			events[i]["lamport"] = hexutil.Uint64(e.Lamport())
+		}
+	}
+	return map[string]interface{}{
+		"atropos": hexutil.Bytes(block.Atropos.Bytes()),
+		"time":    hexutil.Uint64(block.Time),
+		"events":  events,
+	}, nil
+}
+
+// NewEvents sends a notification each time a new event is connected to the DAG,
+// and once again when the event gets confirmed. The "confirmed" field reports the confirmation status,
+// and the "atropos" field is the Atropos of the block which has confirmed the event.
+func (s *PublicDAGChainAPI) NewEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan EventNotify, 128)
+		eventsSub := s.b.SubscribeNewEventNotify(events)
+
+		for {
+			select {
+			case ev := <-events:
+				fields := inter.RPCMarshalEvent(ev.Event)
+				fields["confirmed"] = ev.Atropos != nil
+				if ev.Atropos != nil {
+					fields["atropos"] = hexutil.Bytes(ev.Atropos.Bytes())
+				}
+				_ = notifier.Notify(rpcSub.ID, fields)
+			case <-rpcSub.Err():
+				eventsSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				eventsSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// NewEpochs sends a notification with the new epoch number each time an epoch is sealed.
+func (s *PublicDAGChainAPI) NewEpochs(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		epochs := make(chan idx.Epoch, 16)
+		epochsSub := s.b.SubscribeNewEpochNotify(epochs)
+
+		for {
+			select {
+			case epoch := <-epochs:
+				_ = notifier.Notify(rpcSub.ID, hexutil.Uint64(epoch))
+			case <-rpcSub.Err():
+				epochsSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				epochsSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// SyncStatus returns the synchronization status of the node.
+// estimatedTimeLeft is in seconds, it's 0 if the node is synced or the sync speed is unknown.
+func (s *PublicDAGChainAPI) SyncStatus(ctx context.Context) map[string]interface{} {
+	progress := s.b.Progress()
+	var timeLeft uint64
+	if progress.HighestBlock > progress.CurrentBlock && progress.BlocksPerSecond > 0 {
+		timeLeft = uint64(float64(progress.HighestBlock-progress.CurrentBlock) / progress.BlocksPerSecond)
+	}
+	return map[string]interface{}{
+		"stage":             progress.SyncStage,
+		"currentEpoch":      hexutil.Uint64(progress.CurrentEpoch),
+		"currentBlock":      hexutil.Uint64(progress.CurrentBlock),
+		"currentBlockTime":  hexutil.Uint64(progress.CurrentBlockTime),
+		"highestEpoch":      hexutil.Uint64(progress.HighestEpoch),
+		"highestBlock":      hexutil.Uint64(progress.HighestBlock),
+		"estimatedTimeLeft": hexutil.Uint64(timeLeft),
+	}
+}
+
+// EventStats returns the time between events connection and their confirmation in a block,
+// as observed by this node since its start. Durations are in nanoseconds.
+func (s *PublicDAGChainAPI) EventStats(ctx context.Context) map[string]interface{} {
+	stats := s.b.EventsLatency()
+	return map[string]interface{}{
+		"confirmed":   hexutil.Uint64(stats.Confirmed),
+		"lastLatency": hexutil.Uint64(stats.Last),
+		"meanLatency": hexutil.Uint64(stats.Mean),
+		"maxLatency":  hexutil.Uint64(stats.Max),
+	}
+}
+
+// EpochStats returns statistics of the epoch events: events number, gas power used, gas used by the originated txs
+// and events size per validator,
+// the number of frames, the epoch start time and the seal time. Returns null if the statistics isn't available.
+// * When epoch is -2 the statistics for latest epoch is returned.
+// * When epoch is -1 the statistics for latest sealed epoch is returned.
+func (s *PublicDAGChainAPI) EpochStats(ctx context.Context, epoch rpc.BlockNumber) (map[string]interface{}, error) {
+	stats, err := s.b.GetEpochStats(ctx, epoch)
+	if err != nil || stats == nil {
+		return nil, err
+	}
+	var totalEvents, totalSize uint64
+	validators := make([]map[string]interface{}, len(stats.Validators))
+	for i, v := range stats.Validators {
+		totalEvents += uint64(v.Events)
+		totalSize += v.Size
+		validators[i] = map[string]interface{}{
+			"id":           hexutil.Uint64(v.Validator),
+			"events":       hexutil.Uint64(v.Events),
+			"gasPowerUsed": hexutil.Uint64(v.GasPowerUsed),
+			"gasUsed":      hexutil.Uint64(v.GasUsed),
+			"size":         hexutil.Uint64(v.Size),
+		}
+	}
+	var avgEventSize uint64
+	if totalEvents != 0 {
+		avgEventSize = totalSize / totalEvents
+	}
+	var sealed *hexutil.Uint64
+	if stats.Sealed != 0 {
+		v := hexutil.Uint64(stats.Sealed)
+		sealed = &v
+	}
+	return map[string]interface{}{
+		"epoch":        hexutil.Uint64(stats.Epoch),
+		"start":        hexutil.Uint64(stats.Start),
+		"sealed":       sealed,
+		"frames":       hexutil.Uint64(stats.MaxFrame),
+		"events":       hexutil.Uint64(totalEvents),
+		"avgEventSize": hexutil.Uint64(avgEventSize),
+		"validators":   validators,
+	}, nil
+}
+
+// maxEventsByCreator is the maximum number of events returned by GetEventsByCreator
+const maxEventsByCreator = 1000
+
+// GetEventsByCreator returns IDs of the validator's events of the epoch, starting from fromSeq, in seq order.
+// Forks are returned too. About 1000 IDs are returned per call, "next" is the seq to continue from,
+// it's null if there're no more events. An error is returned if events of the epoch are pruned,
+// or aren't indexed because the DB was upgraded from a version without the index (only the epochs
+// which were current and last sealed at the upgrade time, and the later ones, are indexed).
+// * When epoch is -2 the events for latest epoch are returned.
+// * When epoch is -1 the events for latest sealed epoch are returned.
+func (s *PublicDAGChainAPI) GetEventsByCreator(ctx context.Context, epoch rpc.BlockNumber, creator hexutil.Uint, fromSeq hexutil.Uint) (map[string]interface{}, error) {
+	res, next, err := s.b.GetEventsByCreator(ctx, epoch, idx.ValidatorID(creator), idx.Event(fromSeq), maxEventsByCreator)
+	if err != nil {
+		return nil, err
+	}
+	var nextSeq *hexutil.Uint
+	if next != 0 {
+		v := hexutil.Uint(next)
+		nextSeq = &v
+	}
+	return map[string]interface{}{
+		"events": inter.EventIDsToHex(res),
+		"next":   nextSeq,
+	}, nil
+}
+
 // GetHeads returns IDs of all the epoch events with no descendants.
 // * When epoch is -2 the heads for latest epoch are returned.
 // * When epoch is -1 the heads for latest sealed epoch are returned.
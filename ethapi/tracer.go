@@ -0,0 +1,172 @@
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/opera"
+	"github.com/Fantom-foundation/go-opera/utils/signers/gsignercache"
+)
+
+// defaultTraceTimeout is the amount of time a single transaction can execute
+// by default before being forcefully aborted.
+const defaultTraceTimeout = 5 * time.Second
+
+// TraceConfig holds extra parameters to trace functions.
+type TraceConfig struct {
+	*vm.LogConfig
+	// Tracer is either a name of a built-in JS tracer (e.g. callTracer, prestateTracer)
+	// or a JS code of a custom tracer. The struct logger is used if it's not set
+	Tracer  *string
+	Timeout *string
+}
+
+// TraceTransaction returns the structured logs created during the execution of EVM
+// and returns them as a JSON object, or the result of the requested JS tracer.
+// The preceding transactions of the block are re-executed on top of the parent
+// block state, so the historical state has to be available.
+func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+	tx, blockNumber, index, err := api.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	if blockNumber == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	block, err := api.b.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || index >= uint64(len(block.Transactions)) {
+		return nil, fmt.Errorf("block #%d not found", blockNumber)
+	}
+	statedb, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNumber-1)))
+	if err != nil {
+		return nil, err
+	}
+	if statedb == nil {
+		return nil, fmt.Errorf("state of block #%d isn't available", blockNumber-1)
+	}
+
+	// Re-execute the preceding transactions of the block
+	signer := gsignercache.Wrap(types.MakeSigner(api.b.ChainConfig(), block.Number))
+	for i, prev := range block.Transactions[:index] {
+		msg, err := evmcore.TxAsMessage(prev, signer, block.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		statedb.Prepare(prev.Hash(), i)
+		vmenv, _, err := api.b.GetEVM(ctx, msg, statedb, &block.EvmHeader, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := evmcore.ApplyMessage(vmenv, msg, new(evmcore.GasPool).AddGas(msg.Gas())); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", prev.Hash(), err)
+		}
+		statedb.Finalise(true)
+	}
+
+	msg, err := evmcore.TxAsMessage(tx, signer, block.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	statedb.Prepare(tx.Hash(), int(index))
+	txctx := &tracers.Context{
+		BlockHash: block.Hash,
+		TxIndex:   int(index),
+		TxHash:    tx.Hash(),
+	}
+	return api.traceTx(ctx, msg, txctx, statedb, &block.EvmHeader, config)
+}
+
+// traceTx executes the message with the requested tracer on top of the provided state.
+func (api *PrivateDebugAPI) traceTx(ctx context.Context, msg evmcore.Message, txctx *tracers.Context, statedb *state.StateDB, header *evmcore.EvmHeader, config *TraceConfig) (interface{}, error) {
+	var logConfig vm.LogConfig
+	timeout := defaultTraceTimeout
+	if config != nil {
+		if config.LogConfig != nil {
+			logConfig = *config.LogConfig
+		}
+		if config.Timeout != nil {
+			var err error
+			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var (
+		tracer       vm.Tracer
+		jsTracer     *tracers.Tracer
+		structLogger *vm.StructLogger
+		err          error
+	)
+	if config != nil && config.Tracer != nil {
+		if jsTracer, err = tracers.New(*config.Tracer, txctx); err != nil {
+			return nil, err
+		}
+		tracer = jsTracer
+	} else {
+		structLogger = vm.NewStructLogger(&logConfig)
+		tracer = structLogger
+	}
+
+	vmConfig := opera.DefaultVMConfig
+	vmConfig.Debug = true
+	vmConfig.Tracer = tracer
+	vmenv, _, err := api.b.GetEVM(ctx, msg, statedb, header, &vmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle timeouts and RPC cancellations
+	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	go func() {
+		<-deadlineCtx.Done()
+		if jsTracer != nil {
+			jsTracer.Stop(errors.New("execution timeout"))
+		}
+		vmenv.Cancel()
+	}()
+
+	result, err := evmcore.ApplyMessage(vmenv, msg, new(evmcore.GasPool).AddGas(msg.Gas()))
+	if vmenv.Cancelled() {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing failed: %w", err)
+	}
+
+	if jsTracer != nil {
+		res, err := jsTracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(res), nil
+	}
+	// If the result contains a revert reason, return it
+	returnVal := fmt.Sprintf("%x", result.Return())
+	if len(result.Revert()) > 0 {
+		returnVal = fmt.Sprintf("%x", result.Revert())
+	}
+	return &ExecutionResult{
+		Gas:         result.UsedGas,
+		Failed:      result.Failed(),
+		ReturnValue: returnVal,
+		StructLogs:  FormatLogs(structLogger.StructLogs()),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package ethapi
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// Transaction statuses reported by TxStatus subscription
+const (
+	txStatusAdded     = "added"
+	txStatusDropped   = "dropped"
+	txStatusPacked    = "packed"
+	txStatusFinalized = "finalized"
+	txStatusReverted  = "reverted"
+)
+
+// TxStatus sends a notification each time a transaction changes its status:
+// "added" into the tx pool, "dropped" from the tx pool (the reason is "replaced", "underpriced", "evicted", "overflow", "cap", "nofunds" or "old"),
+// "packed" into an event, "finalized" in a block, or "reverted" if the transaction has failed in a block.
+// A transaction may be packed into several events.
+func (s *PublicTxPoolAPI) TxStatus(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		newTxs := make(chan evmcore.NewTxsNotify, 128)
+		newTxsSub := s.b.SubscribeNewTxsNotify(newTxs)
+		defer newTxsSub.Unsubscribe()
+		droppedTxs := make(chan evmcore.DroppedTxsNotify, 128)
+		droppedTxsSub := s.b.SubscribeDroppedTxsNotify(droppedTxs)
+		defer droppedTxsSub.Unsubscribe()
+		events := make(chan EventNotify, 128)
+		eventsSub := s.b.SubscribeNewEventNotify(events)
+		defer eventsSub.Unsubscribe()
+		blocks := make(chan evmcore.ChainHeadNotify, 16)
+		blocksSub := s.b.SubscribeNewBlockNotify(blocks)
+		defer blocksSub.Unsubscribe()
+
+		send := func(txHash common.Hash, status string, fields map[string]interface{}) {
+			if fields == nil {
+				fields = make(map[string]interface{}, 2)
+			}
+			fields["hash"] = txHash
+			fields["status"] = status
+			_ = notifier.Notify(rpcSub.ID, fields)
+		}
+
+		for {
+			select {
+			case ev := <-newTxs:
+				for _, tx := range ev.Txs {
+					send(tx.Hash(), txStatusAdded, nil)
+				}
+			case ev := <-droppedTxs:
+				for _, tx := range ev.Txs {
+					send(tx.Hash(), txStatusDropped, map[string]interface{}{
+						"reason": ev.Reason,
+					})
+				}
+			case ev := <-events:
+				e, ok := ev.Event.(*inter.EventPayload)
+				if !ok || ev.Atropos != nil {
+					// packed txs are reported once, when the event is connected
+					continue
+				}
+				for _, tx := range e.Txs() {
+					send(tx.Hash(), txStatusPacked, map[string]interface{}{
+						"event": hexutil.Bytes(e.ID().Bytes()),
+					})
+				}
+			case ev := <-blocks:
+				for i, tx := range ev.Block.Transactions {
+					status := txStatusFinalized
+					if i < len(ev.Receipts) && ev.Receipts[i].Status == types.ReceiptStatusFailed {
+						status = txStatusReverted
+					}
+					send(tx.Hash(), status, map[string]interface{}{
+						"block": hexutil.Uint64(ev.Block.NumberU64()),
+					})
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -20,6 +20,18 @@ type Checkers struct {
 
 // Validate runs all the checks except Poset-related
 func (v *Checkers) Validate(e inter.EventPayloadI, parents inter.EventIs) error {
+	if err := v.FastValidate(e, parents); err != nil {
+		return err
+	}
+	if err := v.Heavycheck.ValidateEvent(e); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FastValidate runs all the checks except Poset-related and heavy checks (signatures and payload hash).
+// Suitable only for events which were built and signed locally.
+func (v *Checkers) FastValidate(e inter.EventPayloadI, parents inter.EventIs) error {
 	if err := v.Basiccheck.Validate(e); err != nil {
 		return err
 	}
@@ -36,8 +48,5 @@ func (v *Checkers) Validate(e inter.EventPayloadI, parents inter.EventIs) error
 	if err := v.Gaspowercheck.Validate(e, selfParent); err != nil {
 		return err
 	}
-	if err := v.Heavycheck.ValidateEvent(e); err != nil {
-		return err
-	}
 	return nil
 }
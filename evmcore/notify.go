@@ -24,6 +24,24 @@ import (
 // NewTxsNotify is posted when a batch of transactions enter the transaction pool.
 type NewTxsNotify struct{ Txs []*types.Transaction }
 
+// Reasons of transactions dropping from the transaction pool.
+const (
+	DropReasonReplaced    = "replaced"
+	DropReasonUnderpriced = "underpriced"
+	DropReasonEvicted     = "evicted"
+	DropReasonOverflow    = "overflow"
+	DropReasonCap         = "cap"
+	DropReasonNofunds     = "nofunds"
+	DropReasonOld         = "old"
+)
+
+// DroppedTxsNotify is posted when a batch of transactions leave the transaction pool without being processed.
+// Transactions dropped with DropReasonOld have a nonce which is already used, they may have been processed.
+type DroppedTxsNotify struct {
+	Txs    []*types.Transaction
+	Reason string
+}
+
 // PendingLogsNotify is posted pre mining and notifies of pending logs.
 type PendingLogsNotify struct {
 	Logs []*types.Log
@@ -45,4 +63,7 @@ type ChainSideNotify struct {
 	Block *EvmBlock
 }
 
-type ChainHeadNotify struct{ Block *EvmBlock }
+type ChainHeadNotify struct {
+	Block    *EvmBlock
+	Receipts types.Receipts
+}
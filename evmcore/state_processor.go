@@ -17,6 +17,7 @@
 package evmcore
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -59,6 +60,16 @@ func (p *StateProcessor) Process(
 	block *EvmBlock, statedb *state.StateDB, cfg vm.Config, usedGas *uint64, onNewLog func(*types.Log, *state.StateDB),
 ) (
 	receipts types.Receipts, allLogs []*types.Log, skipped []uint32, err error,
+) {
+	return p.ProcessWithContext(context.Background(), block, statedb, cfg, usedGas, onNewLog)
+}
+
+// ProcessWithContext is Process which stops once the context is done.
+// A running transaction is interrupted via the EVM, and the context error is returned.
+func (p *StateProcessor) ProcessWithContext(
+	ctx context.Context, block *EvmBlock, statedb *state.StateDB, cfg vm.Config, usedGas *uint64, onNewLog func(*types.Log, *state.StateDB),
+) (
+	receipts types.Receipts, allLogs []*types.Log, skipped []uint32, err error,
 ) {
 	skipped = make([]uint32, 0, len(block.Transactions))
 	var (
@@ -72,8 +83,22 @@ func (p *StateProcessor) Process(
 		blockNumber  = block.Number
 		signer       = gsignercache.Wrap(types.MakeSigner(p.config, header.Number))
 	)
+	if done := ctx.Done(); done != nil {
+		finished := make(chan struct{})
+		defer close(finished)
+		go func() {
+			select {
+			case <-done:
+				vmenv.Cancel()
+			case <-finished:
+			}
+		}()
+	}
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
 		msg, err := TxAsMessage(tx, signer, header.BaseFee)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
@@ -92,6 +117,10 @@ func (p *StateProcessor) Process(
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
 	}
+	// the last tx may be interrupted
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
 	return
 }
 
@@ -232,10 +232,13 @@ type TxPool struct {
 	chain       StateReader
 	gasPrice    *big.Int
 	txFeed      notify.Feed
+	dropFeed    notify.Feed
 	scope       notify.SubscriptionScope
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	dropped []DroppedTxsNotify // dropped transactions to notify about on the next reorg
+
 	istanbul bool // Fork indicator whether we are in the istanbul stage.
 	eip2718  bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
@@ -382,6 +385,7 @@ func (pool *TxPool) loop() {
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true)
 					}
+					pool.notifyDropped(DropReasonEvicted, list...)
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
@@ -421,6 +425,26 @@ func (pool *TxPool) SubscribeNewTxsNotify(ch chan<- NewTxsNotify) notify.Subscri
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeDroppedTxsNotify registers a subscription of DroppedTxsNotify and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeDroppedTxsNotify(ch chan<- DroppedTxsNotify) notify.Subscription {
+	return pool.scope.Track(pool.dropFeed.Subscribe(ch))
+}
+
+// notifyDropped schedules the notification about dropped transactions.
+// The notification is sent on the next reorg, to not block the pool.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) notifyDropped(reason string, txs ...*types.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	pool.dropped = append(pool.dropped, DroppedTxsNotify{
+		Txs:    txs,
+		Reason: reason,
+	})
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -445,6 +469,7 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 			pool.removeTx(tx.Hash(), false)
 		}
 		pool.priced.Removed(len(drop))
+		pool.notifyDropped(DropReasonUnderpriced, drop...)
 	}
 
 	log.Info("Transaction pool price threshold updated", "price", price)
@@ -717,6 +742,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			underpricedTxMeter.Mark(1)
 			pool.removeTx(tx.Hash(), false)
 		}
+		pool.notifyDropped(DropReasonUnderpriced, drop...)
 	}
 	// Try to replace an existing transaction in the pending pool
 	from, _ := types.Sender(pool.signer, tx) // already validated
@@ -731,6 +757,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		if old != nil {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
+			pool.notifyDropped(DropReasonReplaced, old)
 			pendingReplaceMeter.Mark(1)
 		}
 		pool.all.Add(tx, isLocal)
@@ -782,6 +809,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		pool.notifyDropped(DropReasonReplaced, old)
 		queuedReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the queued counter
@@ -838,6 +866,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		pool.notifyDropped(DropReasonReplaced, old)
 		pendingReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the pending counter
@@ -1209,8 +1238,15 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 		highestPending := list.LastElement()
 		pool.pendingNonces.set(addr, highestPending.Nonce()+1)
 	}
+	dropped := pool.dropped
+	pool.dropped = nil
 	pool.mu.Unlock()
 
+	// Notify subsystems for dropped transactions
+	for _, ev := range dropped {
+		pool.dropFeed.Send(ev)
+	}
+
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
@@ -1347,6 +1383,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 		}
+		pool.notifyDropped(DropReasonOld, forwards...)
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas)
@@ -1354,6 +1391,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 		}
+		pool.notifyDropped(DropReasonNofunds, drops...)
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
@@ -1377,6 +1415,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 				pool.all.Remove(hash)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
+			pool.notifyDropped(DropReasonCap, caps...)
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
 		// Mark all the items dropped as removed
@@ -1442,6 +1481,7 @@ func (pool *TxPool) truncatePending() {
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
 						log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
+					pool.notifyDropped(DropReasonOverflow, caps...)
 					pool.priced.Removed(len(caps))
 					pendingGauge.Dec(int64(len(caps)))
 					if pool.locals.contains(offenders[i]) {
@@ -1469,6 +1509,7 @@ func (pool *TxPool) truncatePending() {
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
 					log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 				}
+				pool.notifyDropped(DropReasonOverflow, caps...)
 				pool.priced.Removed(len(caps))
 				pendingGauge.Dec(int64(len(caps)))
 				if pool.locals.contains(addr) {
@@ -1509,9 +1550,11 @@ func (pool *TxPool) truncateQueue() {
 
 		// Drop all transactions if they are less than the overflow
 		if size := uint64(list.Len()); size <= drop {
-			for _, tx := range list.Flatten() {
+			txs := list.Flatten()
+			for _, tx := range txs {
 				pool.removeTx(tx.Hash(), true)
 			}
+			pool.notifyDropped(DropReasonOverflow, txs...)
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
 			continue
@@ -1520,6 +1563,7 @@ func (pool *TxPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true)
+			pool.notifyDropped(DropReasonOverflow, txs[i])
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1545,6 +1589,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			pool.all.Remove(hash)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
+		pool.notifyDropped(DropReasonOld, olds...)
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
 		drops, invalids := list.Filter(pool.currentState.GetBalance(addr), pool.currentMaxGas)
 		for _, tx := range drops {
@@ -1552,6 +1597,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
 		}
+		pool.notifyDropped(DropReasonNofunds, drops...)
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
 		for _, tx := range invalids {
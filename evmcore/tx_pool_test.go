@@ -669,6 +669,67 @@ func TestTransactionDropping(t *testing.T) {
 	}
 }
 
+// Tests that the transactions dropped because of a low balance are notified.
+func TestTransactionDroppingNotify(t *testing.T) {
+	t.Parallel()
+
+	// Create a test account and fund it
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	dropped := make(chan DroppedTxsNotify, 16)
+	sub := pool.SubscribeDroppedTxsNotify(dropped)
+	defer sub.Unsubscribe()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000))
+
+	// Add a pending and a queued transaction which become unpayable
+	var (
+		tx0  = transaction(0, 100, key)
+		tx1  = transaction(1, 200, key)
+		tx10 = transaction(10, 100, key)
+		tx11 = transaction(11, 200, key)
+	)
+	pool.all.Add(tx0, false)
+	pool.priced.Put(tx0, false)
+	pool.promoteTx(account, tx0.Hash(), tx0)
+
+	pool.all.Add(tx1, false)
+	pool.priced.Put(tx1, false)
+	pool.promoteTx(account, tx1.Hash(), tx1)
+
+	pool.enqueueTx(tx10.Hash(), tx10, false, true)
+	pool.enqueueTx(tx11.Hash(), tx11, false, true)
+
+	// Reduce the balance of the account, and check that the dropped transactions are notified
+	testAddBalance(pool, account, big.NewInt(-750))
+	<-pool.requestReset(nil, nil)
+
+	if pool.all.Count() != 2 {
+		t.Fatalf("total transaction mismatch: have %d, want %d", pool.all.Count(), 2)
+	}
+	notified := make(map[common.Hash]string)
+	for len(notified) < 2 {
+		select {
+		case ev := <-dropped:
+			for _, tx := range ev.Txs {
+				notified[tx.Hash()] = ev.Reason
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("dropped transactions not notified: have %d, want %d", len(notified), 2)
+		}
+	}
+	for _, tx := range []*types.Transaction{tx1, tx11} {
+		if reason := notified[tx.Hash()]; reason != DropReasonNofunds {
+			t.Errorf("drop reason mismatch for %v: have %q, want %q", tx.Nonce(), reason, DropReasonNofunds)
+		}
+	}
+	if len(notified) != 2 {
+		t.Errorf("dropped transactions mismatch: have %d, want %d", len(notified), 2)
+	}
+}
+
 // Tests that if a transaction is dropped from the current pending pool (e.g. out
 // of fund), all consecutive (still valid, but not executable) transactions are
 // postponed back into the future queue to prevent broadcasting them.
@@ -1,6 +1,8 @@
 package gossip
 
 import (
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -35,3 +37,49 @@ func (api *PublicEthereumAPI) Hashrate() hexutil.Uint64 {
 func (api *PublicEthereumAPI) ChainId() hexutil.Uint64 {
 	return hexutil.Uint64(api.s.store.GetRules().EvmChainConfig().ChainID.Uint64())
 }
+
+// PrivateAdminAPI is the collection of administrative API methods exposed only over a secure RPC channel.
+type PrivateAdminAPI struct {
+	s *Service
+}
+
+// NewPrivateAdminAPI creates a new API definition for the private admin methods of the gossip service.
+func NewPrivateAdminAPI(s *Service) *PrivateAdminAPI {
+	return &PrivateAdminAPI{s}
+}
+
+// PauseEmitter pauses events emission after an event which is being emitted is processed.
+func (api *PrivateAdminAPI) PauseEmitter() (bool, error) {
+	if len(api.s.emitters) == 0 {
+		return false, errors.New("no emitters are registered")
+	}
+	for _, em := range api.s.emitters {
+		em.Pause()
+	}
+	return true, nil
+}
+
+// ResumeEmitter resumes events emission.
+func (api *PrivateAdminAPI) ResumeEmitter() (bool, error) {
+	if len(api.s.emitters) == 0 {
+		return false, errors.New("no emitters are registered")
+	}
+	for _, em := range api.s.emitters {
+		em.Resume()
+	}
+	return true, nil
+}
+
+// AnchorPayload anchors an application payload in the next event of every emitter.
+// The payload is placed into the event's extra data, after the node version which is published in the first event of epoch.
+func (api *PrivateAdminAPI) AnchorPayload(payload hexutil.Bytes) (bool, error) {
+	if len(api.s.emitters) == 0 {
+		return false, errors.New("no emitters are registered")
+	}
+	for _, em := range api.s.emitters {
+		if err := em.SetNextExtra(payload); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
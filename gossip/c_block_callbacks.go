@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 
+	"github.com/Fantom-foundation/go-opera/ethapi"
 	"github.com/Fantom-foundation/go-opera/evmcore"
 	"github.com/Fantom-foundation/go-opera/gossip/blockproc/verwatcher"
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
@@ -78,6 +79,8 @@ func (s *Service) GetConsensusCallbacks() lachesis.ConsensusCallbacks {
 			&s.feed,
 			&s.emitters,
 			s.verWatcher,
+			s.eventsLatency,
+			s.pendingEventTxs,
 		),
 	}
 }
@@ -94,6 +97,8 @@ func consensusCallbackBeginBlockFn(
 	feed *ServiceFeed,
 	emitters *[]*emitter.Emitter,
 	verWatcher *verwatcher.VerWarcher,
+	eventsLatency *eventsLatency,
+	pendingEventTxs *pendingEventTxs,
 ) lachesis.BeginBlockFn {
 	return func(cBlock *lachesis.Block) lachesis.BlockCallbacks {
 		wg.Wait()
@@ -133,6 +138,7 @@ func consensusCallbackBeginBlockFn(
 		return lachesis.BlockCallbacks{
 			ApplyEvent: func(_e dag.Event) {
 				e := _e.(inter.EventI)
+				eventsLatency.Confirmed(e.ID(), start)
 				if cBlock.Atropos == e.ID() {
 					atroposTime = e.MedianTime()
 					atroposDegenerate = false
@@ -190,6 +196,10 @@ func consensusCallbackBeginBlockFn(
 				for _, em := range *emitters {
 					em.OnEventConfirmed(e)
 				}
+				if feed != nil {
+					atropos := cBlock.Atropos
+					feed.sendNewEvent(ethapi.EventNotify{Event: e, Atropos: &atropos})
+				}
 			},
 			EndBlock: func() (newValidators *pos.Validators) {
 				if atroposTime <= bs.LastBlock.Time {
@@ -308,6 +318,7 @@ func consensusCallbackBeginBlockFn(
 					}
 
 					_ = evmProcessor.Execute(txs)
+					pendingEventTxs.Finalize(blockEvents)
 
 					evmBlock, skippedTxs, allReceipts := evmProcessor.Finalize()
 					block.SkippedTxs = skippedTxs
@@ -341,13 +352,18 @@ func consensusCallbackBeginBlockFn(
 					}
 
 					// call OnNewReceipt
+					txsGasUsed := make(map[idx.ValidatorID]uint64)
 					for i, r := range allReceipts {
 						creator := txPositions[r.TxHash].EventCreator
+						if creator != 0 {
+							txsGasUsed[creator] += r.GasUsed
+						}
 						if creator != 0 && es.Validators.Get(creator) == 0 {
 							creator = 0
 						}
 						txListener.OnNewReceipt(evmBlock.Transactions[i], r, creator)
 					}
+					store.addEpochTxsGasUsed(es.Epoch, txsGasUsed)
 					bs = txListener.Finalize() // TODO: refactor to not mutate the bs
 					bs.FinalizedStateRoot = block.Root
 					// At this point, block state is finalized
@@ -407,7 +423,7 @@ func consensusCallbackBeginBlockFn(
 
 					// Notify about new block
 					if feed != nil {
-						feed.newBlock.Send(evmcore.ChainHeadNotify{Block: evmBlock})
+						feed.newBlock.Send(evmcore.ChainHeadNotify{Block: evmBlock, Receipts: allReceipts})
 						var logs []*types.Log
 						for _, r := range allReceipts {
 							for _, l := range r.Logs {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/big"
 	"sync/atomic"
+	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/gossip/dagprocessor"
 	"github.com/Fantom-foundation/lachesis-base/hash"
@@ -11,6 +12,7 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/Fantom-foundation/go-opera/ethapi"
 	"github.com/Fantom-foundation/go-opera/eventcheck"
 	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
@@ -219,6 +221,8 @@ func (s *Service) processEvent(e *inter.EventPayload) error {
 		return err
 	}
 
+	// the event may be confirmed during its processing, so track it beforehand
+	s.eventsLatency.Connected(e.ID(), time.Now())
 	err = s.saveAndProcessEvent(e, &es)
 	if err != nil {
 		return err
@@ -229,6 +233,10 @@ func (s *Service) processEvent(e *inter.EventPayload) error {
 	// index DAG heads and last events
 	s.store.SetHeads(oldEpoch, processEventHeads(s.store.GetHeads(oldEpoch), e))
 	s.store.SetLastEvents(oldEpoch, processLastEvent(s.store.GetLastEvents(oldEpoch), e))
+	s.store.SetCreatorEvent(e)
+	s.packedTxs.Add(e)
+	s.store.updateEpochEventsStats(e)
+	s.pendingEventTxs.Add(e)
 	// update highest Lamport
 	if newEpoch != oldEpoch {
 		s.store.SetHighestLamport(0)
@@ -239,6 +247,7 @@ func (s *Service) processEvent(e *inter.EventPayload) error {
 	for _, em := range s.emitters {
 		em.OnEventConnected(e)
 	}
+	s.feed.sendNewEvent(ethapi.EventNotify{Event: e})
 
 	if newEpoch != oldEpoch {
 		s.switchEpochTo(newEpoch)
@@ -0,0 +1,76 @@
+//go:build chaos
+// +build chaos
+
+package gossip
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	"github.com/Fantom-foundation/go-opera/gossip/simnet"
+)
+
+// ChaosConfig describes faults which are injected into incoming messages of the gossip protocol.
+// It's the same fault model which is used by the simulated network.
+// Handshake messages are never affected, so peers stay connected.
+// A delayed message delays all the following messages of the same peer.
+type ChaosConfig = simnet.Faults
+
+// globalRand is the math/rand source, which is safe for concurrent use
+type globalRand struct{}
+
+func (globalRand) Float64() float64 { return rand.Float64() }
+
+func (globalRand) Int63n(n int64) int64 { return rand.Int63n(n) }
+
+var chaos atomic.Value
+
+// SetChaos sets faults for all the peers of the process, including already connected peers
+func SetChaos(cfg ChaosConfig) {
+	chaos.Store(cfg)
+}
+
+func getChaos() ChaosConfig {
+	cfg, _ := chaos.Load().(ChaosConfig)
+	return cfg
+}
+
+type chaosMsgReadWriter struct {
+	p2p.MsgReadWriter
+	local  enode.ID
+	remote enode.ID
+}
+
+func wrapChaosMsgReadWriter(svc *Service, p *p2p.Peer, rw p2p.MsgReadWriter) p2p.MsgReadWriter {
+	var local enode.ID
+	if svc != nil && svc.p2pServer != nil {
+		local = svc.p2pServer.LocalNode().ID()
+	}
+	return &chaosMsgReadWriter{
+		MsgReadWriter: rw,
+		local:         local,
+		remote:        p.ID(),
+	}
+}
+
+func (rw *chaosMsgReadWriter) ReadMsg() (p2p.Msg, error) {
+	for {
+		msg, err := rw.MsgReadWriter.ReadMsg()
+		if err != nil || msg.Code == HandshakeMsg {
+			return msg, err
+		}
+		cfg := getChaos()
+		if cfg.Drop(globalRand{}, rw.local, rw.remote) {
+			_ = msg.Discard()
+			continue
+		}
+		if delay := cfg.Delay(globalRand{}); delay > 0 {
+			time.Sleep(delay)
+		}
+		return msg, nil
+	}
+}
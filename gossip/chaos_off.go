@@ -0,0 +1,13 @@
+//go:build !chaos
+// +build !chaos
+
+package gossip
+
+import (
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// wrapChaosMsgReadWriter injects no faults unless built with the chaos tag
+func wrapChaosMsgReadWriter(svc *Service, p *p2p.Peer, rw p2p.MsgReadWriter) p2p.MsgReadWriter {
+	return rw
+}
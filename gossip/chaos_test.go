@@ -0,0 +1,70 @@
+//go:build chaos
+// +build chaos
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/simnet"
+)
+
+func TestChaosMsgReadWriter(t *testing.T) {
+	require := require.New(t)
+	defer SetChaos(ChaosConfig{})
+
+	remote := enode.ID{1}
+	in, out := p2p.MsgPipe()
+	defer in.Close()
+	rw := wrapChaosMsgReadWriter(nil, p2p.NewPeer(remote, "remote", nil), out)
+
+	send := func(codes ...uint64) {
+		go func() {
+			for _, code := range codes {
+				_ = p2p.Send(in, code, []uint{})
+			}
+		}()
+	}
+
+	// all the messages except handshake are dropped
+	SetChaos(ChaosConfig{DropRate: 1})
+	send(ProgressMsg, EventsMsg, HandshakeMsg)
+	msg, err := rw.ReadMsg()
+	require.NoError(err)
+	require.Equal(uint64(HandshakeMsg), msg.Code)
+	require.NoError(msg.Discard())
+
+	// messages of partitioned peers are ignored
+	SetChaos(ChaosConfig{
+		Partitioned: func(local, r enode.ID) bool {
+			return r == remote
+		},
+	})
+	send(ProgressMsg, HandshakeMsg)
+	msg, err = rw.ReadMsg()
+	require.NoError(err)
+	require.Equal(uint64(HandshakeMsg), msg.Code)
+	require.NoError(msg.Discard())
+
+	// partitions of the simulated network are applicable to real peers
+	SetChaos(ChaosConfig{
+		Partitioned: simnet.Groups([]enode.ID{remote}),
+	})
+	send(EventsMsg, HandshakeMsg)
+	msg, err = rw.ReadMsg()
+	require.NoError(err)
+	require.Equal(uint64(HandshakeMsg), msg.Code)
+	require.NoError(msg.Discard())
+
+	// no faults
+	SetChaos(ChaosConfig{})
+	send(ProgressMsg)
+	msg, err = rw.ReadMsg()
+	require.NoError(err)
+	require.Equal(uint64(ProgressMsg), msg.Code)
+	require.NoError(msg.Discard())
+}
@@ -131,6 +131,18 @@ func (m testConfirmedEventsModule) Start(bs iblockproc.BlockState, es iblockproc
 }
 
 func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
+	return newTestEnvWithEmitters(firstEpoch, validatorsNum, func(idx.ValidatorID) bool {
+		return true
+	})
+}
+
+// newTestEnvWithEmitters creates a test environment which runs emitters only of the selected validators
+func newTestEnvWithEmitters(firstEpoch idx.Epoch, validatorsNum idx.Validator, withEmitter func(idx.ValidatorID) bool) *testEnv {
+	return newTestEnvWithClock(firstEpoch, validatorsNum, withEmitter, nil)
+}
+
+// newTestEnvWithClock creates a test environment whose emitters use the clock, or the wall clock if it's nil
+func newTestEnvWithClock(firstEpoch idx.Epoch, validatorsNum idx.Validator, withEmitter func(idx.ValidatorID) bool, clock emitter.Clock) *testEnv {
 	rules := opera.FakeNetRules()
 	rules.Epochs.MaxEpochDuration = inter.Timestamp(maxEpochDuration)
 	rules.Blocks.MaxEmptyBlockSkipPeriod = 0
@@ -185,11 +197,15 @@ func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
 		cfg.MaxTxsPerAddress = 10000000
 		_ = valKeystore.Add(pubkey, crypto.FromECDSA(makefakegenesis.FakeKey(vid)), validatorpk.FakePassword)
 		_ = valKeystore.Unlock(pubkey, validatorpk.FakePassword)
+		env.pubkeys = append(env.pubkeys, pubkey)
+		if !withEmitter(vid) {
+			continue
+		}
 		world := env.EmitterWorld(env.signer)
 		world.External = testEmitterWorldExternal{world.External, env}
+		world.Clock = clock
 		em := emitter.NewEmitter(cfg, world)
 		env.RegisterEmitter(em)
-		env.pubkeys = append(env.pubkeys, pubkey)
 		em.Start()
 	}
 
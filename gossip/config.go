@@ -38,6 +38,10 @@ type (
 
 		LatencyImportance    int
 		ThroughputImportance int
+		// MaxFullEventRecipients limits the number of peers which receive a full event on broadcast,
+		// the rest of peers receive only the event ID and fetch the event if it's unknown.
+		// 0 means no limit
+		MaxFullEventRecipients int
 
 		EventsSemaphoreLimit dag.Metric
 		BVsSemaphoreLimit    dag.Metric
@@ -46,6 +50,22 @@ type (
 
 		ProgressBroadcastPeriod time.Duration
 
+		// MaxPeerPenalty is the maximum penalty a peer may get within PeerPenaltyPeriod before it gets dropped and banned for PeerBanPeriod.
+		// A rejected item costs 10 points, a duplicate item costs 1 point. 0 disables the limit
+		MaxPeerPenalty    uint32
+		PeerPenaltyPeriod time.Duration
+		PeerBanPeriod     time.Duration
+		// PeerThrottlePenalty is the penalty after which the broadcasts of a peer are ignored until the end of PeerPenaltyPeriod
+		PeerThrottlePenalty uint32
+
+		// ValidatorPeerSlots is the number of peer slots reserved for peers which prove to be validators of the current epoch,
+		// so validators keep connections to each other under peer churn. At most a half of the max peers is reserved
+		ValidatorPeerSlots int
+
+		// MaxEventTimeAhead is the maximum duration an incoming event's claimed time may be ahead of the local clock,
+		// further events are ignored until the local clock catches up. 0 disables the limit
+		MaxEventTimeAhead time.Duration
+
 		DagProcessor dagprocessor.Config
 		BvProcessor  bvprocessor.Config
 		BrProcessor  brprocessor.Config
@@ -67,6 +87,8 @@ type (
 		RandomTxHashesSendPeriod time.Duration
 
 		PeerCache PeerCacheConfig
+
+		PeerRateLimit PeerRateLimitConfig
 	}
 
 	// Config for the gossip service.
@@ -97,6 +119,9 @@ type (
 		// send-transction variants. The unit is ether.
 		RPCTxFeeCap float64 `toml:",omitempty"`
 
+		// RPCEVMTimeout is the global timeout for eth-call.
+		RPCEVMTimeout time.Duration `toml:",omitempty"`
+
 		// allows only for EIP155 transactions.
 		AllowUnprotectedTxs bool
 
@@ -109,6 +134,8 @@ type (
 		// Cache size for full events.
 		EventsNum  int
 		EventsSize uint
+		// Cache size for event headers.
+		EventsHeadersNum int
 		// Cache size for full blocks.
 		BlocksNum  int
 		BlocksSize uint
@@ -123,9 +150,32 @@ type (
 		EVM                 evmstore.StoreConfig
 		MaxNonFlushedSize   int
 		MaxNonFlushedPeriod time.Duration
+		// Retention defines which historical data is pruned
+		Retention RetentionConfig
+	}
+
+	// RetentionConfig is a config for pruning of historical data. 0 means keeping the data forever, unless specified otherwise
+	RetentionConfig struct {
+		// KeepEventsEpochs is the number of recent epochs to keep events and events statistics of
+		KeepEventsEpochs idx.Epoch
+		// KeepEventHeadersEpochs is the number of epochs prior to KeepEventsEpochs to keep headers of the pruned events of.
+		// 0 means that the headers are pruned together with the events
+		KeepEventHeadersEpochs idx.Epoch
+		// KeepReceiptsBlocks is the number of recent blocks to keep receipts of
+		KeepReceiptsBlocks idx.Block
+		// Period is the interval between prunings
+		Period time.Duration
 	}
 )
 
+// PeerRateLimitConfig limits the rate of items broadcast by a single peer.
+// Messages above the limit are dropped and counted towards the peer's penalty. 0 means no limit
+type PeerRateLimitConfig struct {
+	EventsPerSecond float64
+	TxsPerSecond    float64
+	BytesPerSecond  float64 // limits only the broadcast messages, so responses to our requests are never dropped
+}
+
 type PeerCacheConfig struct {
 	MaxKnownTxs    int // Maximum transactions hashes to keep in the known list (prevent DOS)
 	MaxKnownEvents int // Maximum event hashes to keep in the known list (prevent DOS)
@@ -162,6 +212,12 @@ func DefaultConfig(scale cachescale.Func) Config {
 			},
 			MsgsSemaphoreTimeout:    10 * time.Second,
 			ProgressBroadcastPeriod: 10 * time.Second,
+			MaxPeerPenalty:          10000,
+			PeerPenaltyPeriod:       time.Minute,
+			PeerBanPeriod:           time.Hour,
+			PeerThrottlePenalty:     5000,
+			ValidatorPeerSlots:      10,
+			MaxEventTimeAhead:       time.Hour,
 
 			DagProcessor: dagprocessor.DefaultConfig(scale),
 			BvProcessor:  bvprocessor.DefaultConfig(scale),
@@ -207,8 +263,9 @@ func DefaultConfig(scale cachescale.Func) Config {
 
 		RPCBlockExt: true,
 
-		RPCGasCap:   50000000,
-		RPCTxFeeCap: 100, // 100 FTM
+		RPCGasCap:     50000000,
+		RPCTxFeeCap:   100, // 100 FTM
+		RPCEVMTimeout: 5 * time.Second,
 	}
 	sessionCfg := cfg.Protocol.DagStreamLeecher.Session
 	cfg.Protocol.DagProcessor.EventsBufferLimit.Num = idx.Event(sessionCfg.ParallelChunksDownload)*
@@ -258,6 +315,7 @@ func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 		Cache: StoreCacheConfig{
 			EventsNum:          scale.I(5000),
 			EventsSize:         scale.U(6 * opt.MiB),
+			EventsHeadersNum:   scale.I(5000),
 			BlocksNum:          scale.I(5000),
 			BlocksSize:         scale.U(512 * opt.KiB),
 			BlockEpochStateNum: scale.I(8),
@@ -265,6 +323,9 @@ func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 		EVM:                 evmstore.DefaultStoreConfig(scale),
 		MaxNonFlushedSize:   17*opt.MiB + scale.I(5*opt.MiB),
 		MaxNonFlushedPeriod: 30 * time.Minute,
+		Retention: RetentionConfig{
+			Period: time.Minute,
+		},
 	}
 }
 
@@ -14,9 +14,10 @@ import (
 
 // dummyTxPool is a fake, helper transaction pool for testing purposes
 type dummyTxPool struct {
-	txFeed notify.Feed
-	pool   []*types.Transaction        // Collection of all transactions
-	added  chan<- []*types.Transaction // Notification channel for new transactions
+	txFeed   notify.Feed
+	dropFeed notify.Feed
+	pool     []*types.Transaction        // Collection of all transactions
+	added    chan<- []*types.Transaction // Notification channel for new transactions
 
 	signer types.Signer
 
@@ -60,6 +61,11 @@ func (p *dummyTxPool) ContentFrom(addr common.Address) (types.Transactions, type
 	return nil, nil
 }
 
+// Locals returns no addresses, all the transactions are considered remote
+func (p *dummyTxPool) Locals() []common.Address {
+	return nil
+}
+
 // Pending returns all the transactions known to the pool
 func (p *dummyTxPool) Pending(enforceTips bool) (map[common.Address]types.Transactions, error) {
 	p.lock.RLock()
@@ -87,6 +93,10 @@ func (p *dummyTxPool) SubscribeNewTxsNotify(ch chan<- evmcore.NewTxsNotify) noti
 	return p.txFeed.Subscribe(ch)
 }
 
+func (p *dummyTxPool) SubscribeDroppedTxsNotify(ch chan<- evmcore.DroppedTxsNotify) notify.Subscription {
+	return p.dropFeed.Subscribe(ch)
+}
+
 func (p *dummyTxPool) Map() map[common.Hash]*types.Transaction {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
@@ -18,11 +18,14 @@ type EmitIntervals struct {
 	Confirming                 time.Duration // emit time when there's no txs to originate, but at least 1 tx to confirm
 	ParallelInstanceProtection time.Duration
 	DoublesignProtection       time.Duration
+	Heartbeat                  time.Duration // max emit time of an empty event when NoEmptyEvents is enabled and there's no pending txs, replaces Max
 }
 
 type ValidatorConfig struct {
 	ID     idx.ValidatorID
 	PubKey validatorpk.PubKey
+	// NextPubKey is a new key which replaces PubKey since the epoch where it's registered in the validator's profile
+	NextPubKey validatorpk.PubKey
 }
 
 type FileConfig struct {
@@ -40,8 +43,20 @@ type Config struct {
 
 	MaxTxsPerAddress int
 
+	// OldestTxsGasReserve is a share of event gas reserved for the oldest pending txs, in piecefunc.DecimalUnit units.
+	// It prevents starvation of txs which are underpriced comparing to the rest of the pool
+	OldestTxsGasReserve uint64
+
+	// TxTurnFallback is a period after which a tx may be originated by any validator, regardless of its turn.
+	// Zero value disables the fallback
+	TxTurnFallback time.Duration
+
 	MaxParents idx.Event
 
+	// NoEmptyEvents suppresses emission of events without txs while there's no pending txs,
+	// except of a heartbeat event once in EmitIntervals.Heartbeat
+	NoEmptyEvents bool
+
 	// thresholds on GasLeft
 	LimitedTpsThreshold uint64
 	NoTxsThreshold      uint64
@@ -49,6 +64,9 @@ type Config struct {
 
 	TxsCacheInvalidation time.Duration
 
+	// FullValidation enables all the checks of emitted events, including signatures. Intended for debugging
+	FullValidation bool
+
 	PrevEmittedEventFile FileConfig
 	PrevBlockVotesFile   FileConfig
 	PrevEpochVoteFile    FileConfig
@@ -65,6 +83,7 @@ func DefaultConfig() Config {
 			Confirming:                 120 * time.Millisecond,
 			DoublesignProtection:       27 * time.Minute, // should be greater than MaxEmitInterval
 			ParallelInstanceProtection: 1 * time.Minute,
+			Heartbeat:                  10 * time.Minute,
 		},
 
 		MaxTxsPerAddress: TxTurnNonces,
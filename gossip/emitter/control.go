@@ -74,6 +74,8 @@ func (em *Emitter) isAllowedToEmit(e inter.EventI, eTxs bool, metric ancestor.Me
 			}
 		}
 	}
+	// empty events are emitted only as heartbeats if NoEmptyEvents is enabled
+	heartbeatOnly := em.config.NoEmptyEvents && !eTxs && em.world.TxPool.Count() == 0
 	// Enforce emitting if passed too many time/blocks since previous event
 	{
 		rules := em.world.GetRules()
@@ -81,12 +83,22 @@ func (em *Emitter) isAllowedToEmit(e inter.EventI, eTxs bool, metric ancestor.Me
 		if rules.Economy.BlockMissedSlack > maxBlocks && maxBlocks < rules.Economy.BlockMissedSlack-5 {
 			maxBlocks = rules.Economy.BlockMissedSlack - 5
 		}
-		if passedTime >= em.intervals.Max ||
+		maxInterval := em.intervals.Max
+		if heartbeatOnly {
+			maxInterval = em.intervals.Heartbeat
+		}
+		if passedTime >= maxInterval ||
 			passedBlocks >= maxBlocks*4/5 && metric >= piecefunc.DecimalUnit/2 ||
 			passedBlocks >= maxBlocks {
 			return true
 		}
 	}
+	// Emit only heartbeat events if no txs to originate or confirm
+	{
+		if heartbeatOnly {
+			return false
+		}
+	}
 	// Slow down emitting if power is low
 	{
 		threshold := (em.config.NoTxsThreshold + em.config.EmergencyThreshold) / 2
@@ -132,6 +144,6 @@ func (em *Emitter) recheckIdleTime() {
 	em.world.Lock()
 	defer em.world.Unlock()
 	if em.idle() {
-		em.prevIdleTime = time.Now()
+		em.prevIdleTime = em.world.Clock.Now()
 	}
 }
@@ -1,20 +1,24 @@
 package emitter
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/emitter/ancestor"
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	lru "github.com/hashicorp/golang-lru"
 
+	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
 	"github.com/Fantom-foundation/go-opera/evmcore"
 	"github.com/Fantom-foundation/go-opera/gossip/emitter/originatedtxs"
 	"github.com/Fantom-foundation/go-opera/inter"
@@ -43,11 +47,15 @@ type Emitter struct {
 	prevEmittedAtBlock idx.Block
 	originatedTxs      *originatedtxs.Buffer
 	pendingGas         uint64
+	nextExtra          []byte
 
 	// note: track validators and epoch internally to avoid referring to
 	// validators of a future epoch inside OnEventConnected of last epoch event
 	validators *pos.Validators
 	epoch      idx.Epoch
+	// identity is the ValidatorConfig of the current epoch, or an empty one if the node isn't a validator.
+	// It's read by SignValidatorProof without the world lock
+	identity atomic.Value
 
 	// challenges is deadlines when each validator should emit an event
 	challenges map[idx.ValidatorID]time.Time
@@ -64,16 +72,21 @@ type Emitter struct {
 
 	intervals EmitIntervals
 
+	rand *rand.Rand
+
 	done chan struct{}
 	wg   sync.WaitGroup
 
+	paused uint32
+
 	maxParents idx.Event
 
 	cache struct {
-		sortedTxs *types.TransactionsByPriceAndNonce
-		poolTime  time.Time
-		poolBlock idx.Block
-		poolCount int
+		sortedTxs      *types.TransactionsByPriceAndNonce
+		sortedLocalTxs *types.TransactionsByPriceAndNonce
+		poolTime       time.Time
+		poolBlock      idx.Block
+		poolCount      int
 	}
 
 	emittedEventFile *os.File
@@ -91,7 +104,10 @@ func NewEmitter(
 ) *Emitter {
 	// Randomize event time to decrease chance of 2 parallel instances emitting event at the same time
 	// It increases the chance of detecting parallel instances
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	if world.Clock == nil {
+		world.Clock = wallClock{}
+	}
+	r := rand.New(rand.NewSource(world.Clock.Now().UnixNano()))
 	config.EmitIntervals = config.EmitIntervals.RandomizeEmitTime(r)
 
 	txTime, _ := lru.New(TxTimeBufferSize)
@@ -101,15 +117,17 @@ func NewEmitter(
 		originatedTxs: originatedtxs.New(SenderCountBufferSize),
 		txTime:        txTime,
 		intervals:     config.EmitIntervals,
+		rand:          r,
 		Periodic:      logger.Periodic{Instance: logger.New()},
 	}
 }
 
 // init emitter without starting events emission
 func (em *Emitter) init() {
-	em.syncStatus.startup = time.Now()
-	em.syncStatus.lastConnected = time.Now()
-	em.syncStatus.p2pSynced = time.Now()
+	now := em.world.Clock.Now()
+	em.syncStatus.startup = now
+	em.syncStatus.lastConnected = now
+	em.syncStatus.p2pSynced = now
 	validators, epoch := em.world.GetEpochValidators()
 	em.OnNewEpoch(validators, epoch)
 
@@ -176,15 +194,32 @@ func (em *Emitter) Stop() {
 	em.busyRate.Stop()
 }
 
+// Pause pauses event emission. It waits until an event which is being emitted is processed.
+func (em *Emitter) Pause() {
+	em.world.Lock()
+	defer em.world.Unlock()
+	atomic.StoreUint32(&em.paused, 1)
+}
+
+// Resume resumes event emission after Pause.
+func (em *Emitter) Resume() {
+	atomic.StoreUint32(&em.paused, 0)
+}
+
+// Paused returns true if event emission is paused.
+func (em *Emitter) Paused() bool {
+	return atomic.LoadUint32(&em.paused) != 0
+}
+
 func (em *Emitter) tick() {
 	// track synced time
 	if em.world.PeersNum() == 0 {
 		// connected time ~= last time when it's true that "not connected yet"
-		em.syncStatus.lastConnected = time.Now()
+		em.syncStatus.lastConnected = em.world.Clock.Now()
 	}
 	if !em.world.IsSynced() {
 		// synced time ~= last time when it's true that "not synced yet"
-		em.syncStatus.p2pSynced = time.Now()
+		em.syncStatus.p2pSynced = em.world.Clock.Now()
 	}
 	if em.idle() {
 		em.busyRate.Mark(0)
@@ -197,25 +232,26 @@ func (em *Emitter) tick() {
 
 	em.recheckChallenges()
 	em.recheckIdleTime()
-	if time.Since(em.prevEmittedAtTime) >= em.intervals.Min {
+	if em.world.Clock.Now().Sub(em.prevEmittedAtTime) >= em.intervals.Min {
 		_, _ = em.EmitEvent()
 	}
 }
 
-func (em *Emitter) getSortedTxs() *types.TransactionsByPriceAndNonce {
+// getSortedTxs returns the local transactions and the remote transactions, sorted by price and nonce.
+func (em *Emitter) getSortedTxs() (locals *types.TransactionsByPriceAndNonce, remotes *types.TransactionsByPriceAndNonce) {
 	// Short circuit if pool wasn't updated since the cache was built
 	poolCount := em.world.TxPool.Count()
 	if em.cache.sortedTxs != nil &&
 		em.cache.poolBlock == em.world.GetLatestBlockIndex() &&
 		em.cache.poolCount == poolCount &&
-		time.Since(em.cache.poolTime) < em.config.TxsCacheInvalidation {
-		return em.cache.sortedTxs.Copy()
+		em.world.Clock.Now().Sub(em.cache.poolTime) < em.config.TxsCacheInvalidation {
+		return em.cache.sortedLocalTxs.Copy(), em.cache.sortedTxs.Copy()
 	}
 	// Build the cache
 	pendingTxs, err := em.world.TxPool.Pending(true)
 	if err != nil {
 		em.Log.Error("Tx pool transactions fetching error", "err", err)
-		return nil
+		return nil, nil
 	}
 	for from, txs := range pendingTxs {
 		// Filter the excessive transactions from each sender
@@ -223,12 +259,23 @@ func (em *Emitter) getSortedTxs() *types.TransactionsByPriceAndNonce {
 			pendingTxs[from] = txs[:em.config.MaxTxsPerAddress]
 		}
 	}
-	sortedTxs := types.NewTransactionsByPriceAndNonce(em.world.TxSigner, pendingTxs, em.world.GetRules().Economy.MinGasPrice)
+	// Split the local transactions, which are packed ahead of the remote ones
+	localTxs := make(map[common.Address]types.Transactions)
+	for _, addr := range em.world.TxPool.Locals() {
+		if txs := pendingTxs[addr]; len(txs) > 0 {
+			localTxs[addr] = txs
+			delete(pendingTxs, addr)
+		}
+	}
+	minGasPrice := em.world.GetRules().Economy.MinGasPrice
+	sortedLocalTxs := types.NewTransactionsByPriceAndNonce(em.world.TxSigner, localTxs, minGasPrice)
+	sortedTxs := types.NewTransactionsByPriceAndNonce(em.world.TxSigner, pendingTxs, minGasPrice)
+	em.cache.sortedLocalTxs = sortedLocalTxs
 	em.cache.sortedTxs = sortedTxs
 	em.cache.poolCount = poolCount
 	em.cache.poolBlock = em.world.GetLatestBlockIndex()
-	em.cache.poolTime = time.Now()
-	return sortedTxs.Copy()
+	em.cache.poolTime = em.world.Clock.Now()
+	return sortedLocalTxs.Copy(), sortedTxs.Copy()
 }
 
 func (em *Emitter) EmitEvent() (*inter.EventPayload, error) {
@@ -236,15 +283,18 @@ func (em *Emitter) EmitEvent() (*inter.EventPayload, error) {
 		// short circuit if not a validator
 		return nil, nil
 	}
-	sortedTxs := em.getSortedTxs()
+	sortedLocalTxs, sortedTxs := em.getSortedTxs()
 
 	if em.world.IsBusy() {
 		return nil, nil
 	}
 	em.world.Lock()
 	defer em.world.Unlock()
+	if em.Paused() {
+		return nil, nil
+	}
 
-	e, err := em.createEvent(sortedTxs)
+	e, err := em.createEvent(sortedLocalTxs, sortedTxs)
 	if e == nil || err != nil {
 		return nil, err
 	}
@@ -252,6 +302,7 @@ func (em *Emitter) EmitEvent() (*inter.EventPayload, error) {
 
 	err = em.world.Process(e)
 	if err != nil {
+		connectFailedMeter.Mark(1)
 		em.Log.Error("Self-event connection failed", "err", err.Error())
 		return nil, err
 	}
@@ -263,13 +314,22 @@ func (em *Emitter) EmitEvent() (*inter.EventPayload, error) {
 	if len(e.BlockVotes().Votes) != 0 {
 		em.writeLastEmittedBlockVotes(e.BlockVotes().LastBlock())
 	}
+	// application payload is anchored, don't publish it twice
+	if len(em.nextExtra) != 0 && bytes.Equal(e.Extra(), em.nextExtra) {
+		em.nextExtra = nil
+	}
 	// broadcast the event
 	em.world.Broadcast(e)
 
-	em.prevEmittedAtTime = time.Now() // record time after connecting, to add the event processing time"
+	em.prevEmittedAtTime = em.world.Clock.Now() // record time after connecting, to add the event processing time"
 	em.prevEmittedAtBlock = em.world.GetLatestBlockIndex()
 
 	// metrics
+	countEmittedEvents.Inc(1)
+	emittedGasMeter.Mark(int64(e.GasPowerUsed()))
+	emittedTxsMeter.Mark(int64(e.Txs().Len()))
+	txsPerEventHistogram.Update(int64(e.Txs().Len()))
+	parentsPerEventHistogram.Update(int64(len(e.Parents())))
 	if tracing.Enabled() {
 		for _, t := range e.Txs() {
 			span := tracing.CheckTx(t.Hash(), "Emitter.EmitEvent()")
@@ -280,6 +340,47 @@ func (em *Emitter) EmitEvent() (*inter.EventPayload, error) {
 	return e, nil
 }
 
+// SetNextExtra sets an application payload to be anchored in the next emitted event.
+// The payload is retained until an event with it is emitted. Nil payload cancels a pending one.
+func (em *Emitter) SetNextExtra(extra []byte) error {
+	em.world.Lock()
+	defer em.world.Unlock()
+	if uint32(len(extra)) > em.world.GetRules().Dag.MaxExtraData {
+		return epochcheck.ErrTooBigExtra
+	}
+	em.nextExtra = common.CopyBytes(extra)
+	return nil
+}
+
+// SignValidatorProof signs the digest with the key of the current epoch's validator to prove the validator's identity to peers.
+// Zero ID is returned if the node isn't a validator of the current epoch. It's safe for concurrent use.
+func (em *Emitter) SignValidatorProof(digest []byte) (idx.ValidatorID, []byte, error) {
+	identity, _ := em.identity.Load().(ValidatorConfig)
+	if identity.ID == 0 {
+		return 0, nil, nil
+	}
+	sig, err := em.world.Signer.Sign(identity.PubKey, digest)
+	if err != nil {
+		return 0, nil, err
+	}
+	return identity.ID, sig, nil
+}
+
+// eventExtra returns the extra data of an event with the given seq.
+// Node version is published in the first event of epoch, so an application payload is postponed to the next event.
+func (em *Emitter) eventExtra(seq idx.Event) []byte {
+	if seq <= 1 && len(em.config.VersionToPublish) > 0 {
+		version := []byte("v-" + em.config.VersionToPublish)
+		if uint32(len(version)) <= em.world.GetRules().Dag.MaxExtraData {
+			return version
+		}
+	}
+	if len(em.nextExtra) != 0 {
+		return em.nextExtra
+	}
+	return nil
+}
+
 func (em *Emitter) loadPrevEmitTime() time.Time {
 	prevEventID := em.world.GetLastEvent(em.epoch, em.config.Validator.ID)
 	if prevEventID == nil {
@@ -293,13 +394,14 @@ func (em *Emitter) loadPrevEmitTime() time.Time {
 }
 
 // createEvent is not safe for concurrent use.
-func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*inter.EventPayload, error) {
+func (em *Emitter) createEvent(sortedLocalTxs, sortedTxs *types.TransactionsByPriceAndNonce) (*inter.EventPayload, error) {
 	if !em.isValidator() {
 		return nil, nil
 	}
 
 	if synced := em.logSyncStatus(em.isSyncedToEmit()); !synced {
 		// I'm reindexing my old events, so don't create events until connect all the existing self-events
+		notSyncedMeter.Mark(1)
 		return nil, nil
 	}
 
@@ -313,6 +415,7 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 	// Find parents
 	selfParent, parents, ok := em.chooseParents(em.epoch, em.config.Validator.ID)
 	if !ok {
+		noParentsMeter.Mark(1)
 		return nil, nil
 	}
 
@@ -327,6 +430,7 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 		if parentHeaders[i].Creator() == em.config.Validator.ID && i != 0 {
 			// there are 2 heads from me, i.e. due to a fork, chooseParents could have found multiple self-parents
 			em.Periodic.Error(5*time.Second, "I've created a fork, events emitting isn't allowed", "creator", em.config.Validator.ID)
+			forkMeter.Mark(1)
 			return nil, nil
 		}
 		maxLamport = idx.MaxLamport(maxLamport, parent.Lamport())
@@ -354,32 +458,33 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 
 	mutEvent.SetParents(parents)
 	mutEvent.SetLamport(maxLamport + 1)
-	mutEvent.SetCreationTime(inter.MaxTimestamp(inter.Timestamp(time.Now().UnixNano()), selfParentTime+1))
+	mutEvent.SetCreationTime(inter.MaxTimestamp(inter.Timestamp(em.world.Clock.Now().UnixNano()), selfParentTime+1))
 
 	// add LLR votes
 	em.addLlrEpochVote(mutEvent)
 	em.addLlrBlockVotes(mutEvent)
 
-	// node version
-	if mutEvent.Seq() <= 1 && len(em.config.VersionToPublish) > 0 {
-		version := []byte("v-" + em.config.VersionToPublish)
-		if uint32(len(version)) <= em.world.GetRules().Dag.MaxExtraData {
-			mutEvent.SetExtra(version)
-		}
+	// node version or application payload
+	if extra := em.eventExtra(mutEvent.Seq()); extra != nil {
+		mutEvent.SetExtra(extra)
 	}
 
 	// set consensus fields
 	var metric ancestor.Metric
+	buildStart := time.Now()
 	err := em.world.Build(mutEvent, func() {
 		// calculate event metric when it is indexed by the vector clock
 		metric = eventMetric(em.quorumIndexer.GetMetricOf(mutEvent.ID()), mutEvent.Seq())
 		metric = overheadAdjustedEventMetricF(em.validators.Len(), uint64(em.busyRate.Rate1()*piecefunc.DecimalUnit), metric)
 	})
+	buildTimer.UpdateSince(buildStart)
 	if err != nil {
 		if err == ErrNotEnoughGasPower {
+			noGasPowerMeter.Mark(1)
 			em.Periodic.Warn(time.Second, "Not enough gas power to emit event. Too small stake?",
 				"stake%", 100*float64(em.validators.Get(em.config.Validator.ID))/float64(em.validators.TotalWeight()))
 		} else {
+			buildFailedMeter.Mark(1)
 			em.Log.Warn("Dropped event while emitting", "err", err)
 		}
 		return nil, nil
@@ -388,16 +493,18 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 	// Pre-check if event should be emitted
 	// It is checked in advance to avoid adding transactions just to immediately drop the event later
 	if !em.isAllowedToEmit(mutEvent, true, metric, selfParentHeader) {
+		notAllowedMeter.Mark(1)
 		return nil, nil
 	}
 
-	// Add txs
-	em.addTxs(mutEvent, sortedTxs)
+	// Add txs, local ones go first
+	em.addTxs(mutEvent, sortedLocalTxs, sortedTxs)
 
 	// Check if event should be emitted
 	// Check only if no txs were added, since check in a case with added txs was performed above
 	if mutEvent.Txs().Len() == 0 {
 		if !em.isAllowedToEmit(mutEvent, mutEvent.Txs().Len() != 0, metric, selfParentHeader) {
+			notAllowedMeter.Mark(1)
 			return nil, nil
 		}
 	}
@@ -406,8 +513,11 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 	mutEvent.SetPayloadHash(inter.CalcPayloadHash(mutEvent))
 
 	// sign
+	signStart := time.Now()
 	bSig, err := em.world.Signer.Sign(em.config.Validator.PubKey, mutEvent.HashToSign().Bytes())
+	signTimer.UpdateSince(signStart)
 	if err != nil {
+		signFailedMeter.Mark(1)
 		em.Periodic.Error(time.Second, "Failed to sign event", "err", err)
 		return nil, err
 	}
@@ -419,7 +529,8 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 	event := mutEvent.Build()
 
 	// check
-	if err := em.world.Check(event, parentHeaders); err != nil {
+	if err := em.checkEvent(event, parentHeaders); err != nil {
+		checkFailedMeter.Mark(1)
 		em.Periodic.Error(time.Second, "Emitted incorrect event", "err", err)
 		return nil, err
 	}
@@ -430,6 +541,14 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*i
 	return event, nil
 }
 
+func (em *Emitter) checkEvent(e *inter.EventPayload, parents inter.Events) error {
+	if em.config.FullValidation {
+		return em.world.Check(e, parents)
+	}
+	// signature and payload hash were just calculated by the emitter
+	return em.world.FastCheck(e, parents)
+}
+
 func (em *Emitter) idle() bool {
 	return em.originatedTxs.Empty()
 }
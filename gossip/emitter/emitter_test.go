@@ -1,6 +1,7 @@
 package emitter
 
 import (
+	"math"
 	"math/big"
 	"testing"
 	"time"
@@ -10,13 +11,17 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/inter/pos"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
 	"github.com/Fantom-foundation/go-opera/gossip/emitter/mock"
 	"github.com/Fantom-foundation/go-opera/integration/makefakegenesis"
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
 	"github.com/Fantom-foundation/go-opera/opera"
+	"github.com/Fantom-foundation/go-opera/utils/piecefunc"
 	"github.com/Fantom-foundation/go-opera/vecmt"
 )
 
@@ -101,7 +106,254 @@ func TestEmitter(t *testing.T) {
 		require.True(got.Before(after))
 	})
 
+	t.Run("isMyTxTurn", func(t *testing.T) {
+		require := require.New(t)
+		txHash := common.Hash{1}
+		now := time.Now()
+		em.txTime.Add(txHash, now.Add(-time.Hour))
+
+		em.config.TxTurnFallback = 0
+		turns := 0
+		for _, id := range validators.IDs() {
+			if em.isMyTxTurn(txHash, common.Address{}, 0, now, validators, id, 1) {
+				turns++
+			}
+		}
+		require.LessOrEqual(turns, 1)
+
+		em.config.TxTurnFallback = time.Minute
+		for _, id := range validators.IDs() {
+			require.True(em.isMyTxTurn(txHash, common.Address{}, 0, now, validators, id, 1))
+		}
+		em.config.TxTurnFallback = 0
+	})
+
+	t.Run("addTxs", func(t *testing.T) {
+		require := require.New(t)
+		rules := opera.FakeNetRules()
+
+		senders := make(map[common.Hash]common.Address)
+		txSigner.EXPECT().Equal(gomock.Any()).
+			Return(true).
+			AnyTimes()
+		txSigner.EXPECT().Sender(gomock.Any()).
+			DoAndReturn(func(tx *types.Transaction) (common.Address, error) {
+				return senders[tx.Hash()], nil
+			}).
+			AnyTimes()
+		txPool.EXPECT().Has(gomock.Any()).
+			Return(true).
+			AnyTimes()
+
+		em.config.TxTurnFallback = time.Minute
+		em.config.LimitedTpsThreshold = 0
+		em.config.NoTxsThreshold = 0
+		defer func() {
+			em.config = cfg
+		}()
+
+		now := time.Now()
+		newTx := func(i int, price *big.Int, age time.Duration) *types.Transaction {
+			tx := types.NewTransaction(0, common.Address{byte(i), byte(i >> 8)}, big.NewInt(1), params.TxGas, price, nil)
+			senders[tx.Hash()] = common.BigToAddress(big.NewInt(int64(i + 1)))
+			em.txTime.Add(tx.Hash(), now.Add(-age))
+			return tx
+		}
+		sortTxs := func(txs ...*types.Transaction) *types.TransactionsByPriceAndNonce {
+			m := make(map[common.Address]types.Transactions)
+			for _, tx := range txs {
+				m[senders[tx.Hash()]] = append(m[senders[tx.Hash()]], tx)
+			}
+			return types.NewTransactionsByPriceAndNonce(txSigner, m, rules.Economy.MinGasPrice)
+		}
+		newEvent := func() *inter.MutableEventPayload {
+			e := &inter.MutableEventPayload{}
+			e.SetCreator(cfg.Validator.ID)
+			e.SetGasPowerLeft(inter.GasPowerLeft{Gas: [2]uint64{math.MaxUint64 / 2, math.MaxUint64 / 2}})
+			return e
+		}
+		contains := func(txs types.Transactions, tx *types.Transaction) bool {
+			for _, got := range txs {
+				if got.Hash() == tx.Hash() {
+					return true
+				}
+			}
+			return false
+		}
+
+		// more well-paid txs than an event can fit
+		remotes := types.Transactions{}
+		for i := 0; i < int(rules.Economy.Gas.MaxEventGas/params.TxGas)+100; i++ {
+			remotes = append(remotes, newTx(i, big.NewInt(1e12), time.Hour))
+		}
+		oldest := newTx(len(remotes), rules.Economy.MinGasPrice, 2*time.Hour)
+		remotes = append(remotes, oldest)
+
+		// the underpriced tx starves without the reserve
+		em.config.OldestTxsGasReserve = 0
+		e := newEvent()
+		em.addTxs(e, sortTxs(), sortTxs(remotes...))
+		require.NotEmpty(e.Txs())
+		require.False(contains(e.Txs(), oldest))
+
+		em.config.OldestTxsGasReserve = piecefunc.DecimalUnit / 100
+		e = newEvent()
+		em.addTxs(e, sortTxs(), sortTxs(remotes...))
+		require.True(contains(e.Txs(), oldest))
+
+		// the reserve is shared by locals and remotes, the oldest txs go first
+		older := newTx(len(remotes), rules.Economy.MinGasPrice, 3*time.Hour)
+		e = newEvent()
+		added := em.addOldestTxs(e, params.TxGas*3/2, sortTxs(oldest), sortTxs(older))
+		require.Equal(map[common.Address]bool{senders[older.Hash()]: true}, added)
+		require.Equal(types.Transactions{older}, e.Txs())
+	})
+
+	t.Run("SetNextExtra", func(t *testing.T) {
+		require := require.New(t)
+		version := []byte("v-" + em.config.VersionToPublish)
+		payload := []byte("payload")
+
+		require.Equal(version, em.eventExtra(1))
+		require.Nil(em.eventExtra(2))
+
+		require.Equal(epochcheck.ErrTooBigExtra, em.SetNextExtra(make([]byte, opera.FakeNetRules().Dag.MaxExtraData+1)))
+		require.NoError(em.SetNextExtra(payload))
+		// node version isn't replaced by the payload
+		require.Equal(version, em.eventExtra(1))
+		require.Equal(payload, em.eventExtra(2))
+
+		require.NoError(em.SetNextExtra(nil))
+		require.Nil(em.eventExtra(2))
+	})
+
+	t.Run("isAllowedToEmit", func(t *testing.T) {
+		require := require.New(t)
+
+		poolTxs := 0
+		txPool.EXPECT().Count().
+			DoAndReturn(func() int { return poolTxs }).
+			AnyTimes()
+		external.EXPECT().GetLatestBlockIndex().
+			Return(idx.Block(0)).
+			AnyTimes()
+
+		start := time.Now()
+		em.prevEmittedAtTime = start
+		em.prevIdleTime = start
+		em.prevEmittedAtBlock = 0
+		em.intervals.Max = 10 * time.Minute
+		em.intervals.Heartbeat = 30 * time.Minute
+		em.config.NoEmptyEvents = true
+		defer func() {
+			em.config.NoEmptyEvents = false
+		}()
+
+		isAllowedToEmit := func(passed time.Duration, eTxs bool) bool {
+			e := &inter.MutableEventPayload{}
+			e.SetCreator(cfg.Validator.ID)
+			e.SetCreationTime(inter.Timestamp(start.Add(passed).UnixNano()))
+			e.SetGasPowerLeft(inter.GasPowerLeft{Gas: [2]uint64{math.MaxUint64 / 2, math.MaxUint64 / 2}})
+			return em.isAllowedToEmit(e, eTxs, piecefunc.DecimalUnit, nil)
+		}
+
+		// empty events are emitted only as heartbeats, even after Max
+		require.False(isAllowedToEmit(20*time.Minute, false))
+		require.True(isAllowedToEmit(30*time.Minute, false))
+		// events with txs, or while there're pending txs, are emitted at least once in Max
+		require.True(isAllowedToEmit(20*time.Minute, true))
+		poolTxs = 1
+		require.True(isAllowedToEmit(20*time.Minute, false))
+		poolTxs = 0
+
+		// without NoEmptyEvents, empty events are emitted once in Max
+		em.config.NoEmptyEvents = false
+		require.True(isAllowedToEmit(20*time.Minute, false))
+	})
+
 	t.Run("tick", func(t *testing.T) {
 		em.tick()
 	})
 }
+
+func TestEmitterPubKeyRotation(t *testing.T) {
+	gValidators := makefakegenesis.GetFakeValidators(3)
+	vv := pos.NewBuilder()
+	for _, v := range gValidators {
+		vv.Set(v.ID, pos.Weight(1))
+	}
+	validators := vv.Build()
+
+	oldKey := validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{1}}
+	newKey := validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{2}}
+
+	newEmitter := func(t *testing.T, registered *validatorpk.PubKey) *Emitter {
+		cfg := DefaultConfig()
+		cfg.Validator.ID = gValidators[0].ID
+		cfg.Validator.PubKey = oldKey
+		cfg.Validator.NextPubKey = newKey
+
+		ctrl := gomock.NewController(t)
+		external := mock.NewMockExternal(ctrl)
+		external.EXPECT().GetRules().
+			Return(opera.FakeNetRules()).
+			AnyTimes()
+		external.EXPECT().GetEpochValidators().
+			Return(validators, idx.Epoch(1)).
+			AnyTimes()
+		external.EXPECT().GetLastEvent(gomock.Any(), cfg.Validator.ID).
+			Return((*hash.Event)(nil)).
+			AnyTimes()
+		external.EXPECT().GetGenesisTime().
+			Return(inter.Timestamp(uint64(time.Now().UnixNano()))).
+			AnyTimes()
+		external.EXPECT().DagIndex().
+			Return((*vecmt.Index)(nil)).
+			AnyTimes()
+		external.EXPECT().GetValidatorPubKey(cfg.Validator.ID).
+			DoAndReturn(func(idx.ValidatorID) validatorpk.PubKey {
+				return *registered
+			}).
+			AnyTimes()
+
+		return NewEmitter(cfg, World{
+			External: external,
+			TxPool:   mock.NewMockTxPool(ctrl),
+			Signer:   mock.NewMockSigner(ctrl),
+			TxSigner: mock.NewMockTxSigner(ctrl),
+		})
+	}
+
+	t.Run("epoch boundary", func(t *testing.T) {
+		require := require.New(t)
+		registered := oldKey
+		em := newEmitter(t, &registered)
+		em.init()
+		require.Equal(oldKey, em.config.Validator.PubKey)
+
+		// the new key isn't registered yet
+		em.OnNewEpoch(validators, 2)
+		require.Equal(oldKey, em.config.Validator.PubKey)
+		require.Equal(newKey, em.config.Validator.NextPubKey)
+
+		// the new key is registered since the next epoch
+		registered = newKey
+		em.OnNewEpoch(validators, 3)
+		require.Equal(newKey, em.config.Validator.PubKey)
+		require.True(em.config.Validator.NextPubKey.Empty())
+
+		// the key stays rotated in the following epochs
+		em.OnNewEpoch(validators, 4)
+		require.Equal(newKey, em.config.Validator.PubKey)
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		require := require.New(t)
+		// the new key was registered while the node was down
+		registered := newKey
+		em := newEmitter(t, &registered)
+		em.init()
+		require.Equal(newKey, em.config.Validator.PubKey)
+		require.True(em.config.Validator.NextPubKey.Empty())
+	})
+}
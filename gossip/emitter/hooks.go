@@ -1,6 +1,7 @@
 package emitter
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/emitter/ancestor"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
 	"github.com/Fantom-foundation/go-opera/utils/adapters/vecmt2dagidx"
 )
 
@@ -23,15 +25,18 @@ func (em *Emitter) OnNewEpoch(newValidators *pos.Validators, newEpoch idx.Epoch)
 		em.maxParents = rules.Dag.MaxParents
 	}
 	if em.validators != nil && em.isValidator() && !em.validators.Exists(em.config.Validator.ID) && newValidators.Exists(em.config.Validator.ID) {
-		em.syncStatus.becameValidator = time.Now()
+		em.syncStatus.becameValidator = em.world.Clock.Now()
 	}
 
 	em.validators, em.epoch = newValidators, newEpoch
 
 	if !em.isValidator() {
+		em.identity.Store(ValidatorConfig{})
 		return
 	}
 	em.prevEmittedAtTime = em.loadPrevEmitTime()
+	em.mayRotatePubKey()
+	em.identity.Store(em.config.Validator)
 
 	em.originatedTxs.Clear()
 	em.pendingGas = 0
@@ -50,6 +55,20 @@ func (em *Emitter) OnNewEpoch(newValidators *pos.Validators, newEpoch idx.Epoch)
 	em.payloadIndexer = ancestor.NewPayloadIndexer(PayloadIndexerSize)
 }
 
+// mayRotatePubKey switches to the next validator key once it's registered in the validator's profile
+func (em *Emitter) mayRotatePubKey() {
+	next := em.config.Validator.NextPubKey
+	if next.Empty() {
+		return
+	}
+	if !bytes.Equal(em.world.GetValidatorPubKey(em.config.Validator.ID).Bytes(), next.Bytes()) {
+		return
+	}
+	em.Log.Info("Validator key is rotated", "old", em.config.Validator.PubKey.String(), "new", next.String())
+	em.config.Validator.PubKey = next
+	em.config.Validator.NextPubKey = validatorpk.PubKey{}
+}
+
 // OnEventConnected tracks new events
 func (em *Emitter) OnEventConnected(e inter.EventPayloadI) {
 	if !em.isValidator() {
@@ -0,0 +1,28 @@
+package emitter
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	countEmittedEvents = metrics.GetOrRegisterCounter("gossip/emitter/events", nil)
+	emittedGasMeter    = metrics.GetOrRegisterMeter("gossip/emitter/gas", nil)
+	emittedTxsMeter    = metrics.GetOrRegisterMeter("gossip/emitter/txs", nil)
+
+	txsPerEventHistogram     = metrics.GetOrRegisterHistogram("gossip/emitter/event/txs", nil, metrics.NewExpDecaySample(1028, 0.015))
+	parentsPerEventHistogram = metrics.GetOrRegisterHistogram("gossip/emitter/event/parents", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+	buildTimer = metrics.GetOrRegisterTimer("gossip/emitter/build", nil)
+	signTimer  = metrics.GetOrRegisterTimer("gossip/emitter/sign", nil)
+
+	// reasons of rejected emission
+	notSyncedMeter     = metrics.GetOrRegisterMeter("gossip/emitter/rejected/notsynced", nil)
+	noParentsMeter     = metrics.GetOrRegisterMeter("gossip/emitter/rejected/noparents", nil)
+	forkMeter          = metrics.GetOrRegisterMeter("gossip/emitter/rejected/fork", nil)
+	noGasPowerMeter    = metrics.GetOrRegisterMeter("gossip/emitter/rejected/gaspower", nil)
+	buildFailedMeter   = metrics.GetOrRegisterMeter("gossip/emitter/rejected/build", nil)
+	notAllowedMeter    = metrics.GetOrRegisterMeter("gossip/emitter/rejected/notallowed", nil)
+	signFailedMeter    = metrics.GetOrRegisterMeter("gossip/emitter/rejected/sign", nil)
+	checkFailedMeter   = metrics.GetOrRegisterMeter("gossip/emitter/rejected/check", nil)
+	connectFailedMeter = metrics.GetOrRegisterMeter("gossip/emitter/rejected/connect", nil)
+)
@@ -98,6 +98,20 @@ func (mr *MockExternalMockRecorder) DagIndex() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DagIndex", reflect.TypeOf((*MockExternal)(nil).DagIndex))
 }
 
+// FastCheck mocks base method
+func (m *MockExternal) FastCheck(arg0 *inter.EventPayload, arg1 inter.Events) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FastCheck", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FastCheck indicates an expected call of FastCheck
+func (mr *MockExternalMockRecorder) FastCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FastCheck", reflect.TypeOf((*MockExternal)(nil).FastCheck), arg0, arg1)
+}
+
 // GetBlockEpoch mocks base method
 func (m *MockExternal) GetBlockEpoch(arg0 idx.Block) idx.Epoch {
 	m.ctrl.T.Helper()
@@ -197,6 +211,20 @@ func (mr *MockExternalMockRecorder) GetGenesisTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesisTime", reflect.TypeOf((*MockExternal)(nil).GetGenesisTime))
 }
 
+// GetValidatorPubKey mocks base method
+func (m *MockExternal) GetValidatorPubKey(arg0 idx.ValidatorID) validatorpk.PubKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorPubKey", arg0)
+	ret0, _ := ret[0].(validatorpk.PubKey)
+	return ret0
+}
+
+// GetValidatorPubKey indicates an expected call of GetValidatorPubKey
+func (mr *MockExternalMockRecorder) GetValidatorPubKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorPubKey", reflect.TypeOf((*MockExternal)(nil).GetValidatorPubKey), arg0)
+}
+
 // GetHeads mocks base method
 func (m *MockExternal) GetHeads(arg0 idx.Epoch) hash.Events {
 	m.ctrl.T.Helper()
@@ -440,6 +468,20 @@ func (mr *MockTxPoolMockRecorder) Has(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockTxPool)(nil).Has), arg0)
 }
 
+// Locals mocks base method
+func (m *MockTxPool) Locals() []common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Locals")
+	ret0, _ := ret[0].([]common.Address)
+	return ret0
+}
+
+// Locals indicates an expected call of Locals
+func (mr *MockTxPoolMockRecorder) Locals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locals", reflect.TypeOf((*MockTxPool)(nil).Locals))
+}
+
 // Pending mocks base method
 func (m *MockTxPool) Pending(arg0 bool) (map[common.Address]types.Transactions, error) {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package emitter
 
 import (
+	"sort"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
@@ -14,7 +15,9 @@ import (
 	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
 	"github.com/Fantom-foundation/go-opera/eventcheck/gaspowercheck"
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/opera"
 	"github.com/Fantom-foundation/go-opera/utils"
+	"github.com/Fantom-foundation/go-opera/utils/piecefunc"
 )
 
 const (
@@ -108,7 +111,7 @@ func (em *Emitter) memorizeTxTimes(txs types.Transactions) {
 	if em.config.Validator.ID == 0 {
 		return // short circuit if not a validator
 	}
-	now := time.Now()
+	now := em.world.Clock.Now()
 	for _, tx := range txs {
 		_, ok := em.txTime.Get(tx.Hash())
 		if !ok {
@@ -128,7 +131,7 @@ func getTxRoundIndex(now, txTime time.Time, validatorsNum idx.Validator) int {
 func (em *Emitter) getTxTime(txHash common.Hash) time.Time {
 	txTimeI, ok := em.txTime.Get(txHash)
 	if !ok {
-		now := time.Now()
+		now := em.world.Clock.Now()
 		em.txTime.Add(txHash, now)
 		return now
 	}
@@ -139,6 +142,11 @@ func (em *Emitter) getTxTime(txHash common.Hash) time.Time {
 func (em *Emitter) isMyTxTurn(txHash common.Hash, sender common.Address, accountNonce uint64, now time.Time, validators *pos.Validators, me idx.ValidatorID, epoch idx.Epoch) bool {
 	txTime := em.getTxTime(txHash)
 
+	if em.config.TxTurnFallback != 0 && now.Sub(txTime) >= em.config.TxTurnFallback {
+		// tx wasn't originated by responsible validators in time, so it's everyone's turn
+		return true
+	}
+
 	roundIndex := getTxRoundIndex(now, txTime, validators.Len())
 	if roundIndex != getTxRoundIndex(now.Add(TxTurnPeriodLatency), txTime, validators.Len()) {
 		// round is about to change, avoid originating the transaction to avoid racing with another validator
@@ -150,18 +158,89 @@ func (em *Emitter) isMyTxTurn(txHash common.Hash, sender common.Address, account
 	return validators.GetID(idx.Validator(rounds[roundIndex])) == me
 }
 
-func (em *Emitter) addTxs(e *inter.MutableEventPayload, sorted *types.TransactionsByPriceAndNonce) {
+func (em *Emitter) isTxOriginable(tx *types.Transaction, sender common.Address, creator idx.ValidatorID, rules opera.Rules) bool {
+	// check transaction epoch rules
+	if epochcheck.CheckTxs(types.Transactions{tx}, rules) != nil {
+		return false
+	}
+	// check not conflicted with already originated txs (in any connected event)
+	if em.originatedTxs.TotalOf(sender) != 0 {
+		return false
+	}
+	// my turn, i.e. try to not include the same tx simultaneously by different validators
+	if !em.isMyTxTurn(tx.Hash(), sender, tx.Nonce(), em.world.Clock.Now(), em.validators, creator, em.epoch) {
+		return false
+	}
+	// check transaction is not outdated
+	return em.world.TxPool.Has(tx.Hash())
+}
+
+// addOldestTxs adds the first txs of senders in order of the txs age, until the gas limit is reached.
+// Returns the senders whose txs were added.
+func (em *Emitter) addOldestTxs(e *inter.MutableEventPayload, maxGasUsed uint64, sortedSets ...*types.TransactionsByPriceAndNonce) map[common.Address]bool {
+	heads := make(types.Transactions, 0, 64)
+	for _, sorted := range sortedSets {
+		for tx := sorted.Peek(); tx != nil; tx = sorted.Peek() {
+			heads = append(heads, tx)
+			sorted.Pop()
+		}
+	}
+	txTimes := make(map[common.Hash]time.Time, len(heads))
+	for _, tx := range heads {
+		txTimes[tx.Hash()] = em.getTxTime(tx.Hash())
+	}
+	sort.SliceStable(heads, func(i, j int) bool {
+		return txTimes[heads[i].Hash()].Before(txTimes[heads[j].Hash()])
+	})
+
+	added := make(map[common.Address]bool)
+	rules := em.world.GetRules()
+	for _, tx := range heads {
+		if params.TxGas >= e.GasPowerLeft().Min() || e.GasPowerUsed()+params.TxGas >= maxGasUsed {
+			// stop if cannot originate even an empty transaction
+			break
+		}
+		if tx.Gas() >= e.GasPowerLeft().Min() || e.GasPowerUsed()+tx.Gas() >= maxGasUsed {
+			continue
+		}
+		sender, _ := types.Sender(em.world.TxSigner, tx)
+		if added[sender] || !em.isTxOriginable(tx, sender, e.Creator(), rules) {
+			continue
+		}
+		// add
+		e.SetGasPowerUsed(e.GasPowerUsed() + tx.Gas())
+		e.SetGasPowerLeft(e.GasPowerLeft().Sub(tx.Gas()))
+		e.SetTxs(append(e.Txs(), tx))
+		added[sender] = true
+	}
+	return added
+}
+
+// addTxs adds the local txs first and then the remote ones.
+// A share of gas is reserved for the oldest txs of both sets to avoid their starvation.
+func (em *Emitter) addTxs(e *inter.MutableEventPayload, sortedLocals, sorted *types.TransactionsByPriceAndNonce) {
 	maxGasUsed := em.maxGasPowerToUse(e)
 	if maxGasUsed <= e.GasPowerUsed() {
 		return
 	}
 
+	var oldestSenders map[common.Address]bool
+	if em.config.OldestTxsGasReserve != 0 {
+		reserved := (maxGasUsed - e.GasPowerUsed()) * em.config.OldestTxsGasReserve / piecefunc.DecimalUnit
+		oldestSenders = em.addOldestTxs(e, e.GasPowerUsed()+reserved, sortedLocals.Copy(), sorted.Copy())
+	}
+
+	em.addSortedTxs(e, sortedLocals, maxGasUsed, oldestSenders)
+	em.addSortedTxs(e, sorted, maxGasUsed, oldestSenders)
+}
+
+func (em *Emitter) addSortedTxs(e *inter.MutableEventPayload, sorted *types.TransactionsByPriceAndNonce, maxGasUsed uint64, skipSenders map[common.Address]bool) {
 	// sort transactions by price and nonce
 	rules := em.world.GetRules()
 	for tx := sorted.Peek(); tx != nil; tx = sorted.Peek() {
 		sender, _ := types.Sender(em.world.TxSigner, tx)
-		// check transaction epoch rules
-		if epochcheck.CheckTxs(types.Transactions{tx}, rules) != nil {
+		// skip senders which were already handled by the oldest txs
+		if skipSenders[sender] {
 			sorted.Pop()
 			continue
 		}
@@ -174,18 +253,7 @@ func (em *Emitter) addTxs(e *inter.MutableEventPayload, sorted *types.Transactio
 			sorted.Pop()
 			continue
 		}
-		// check not conflicted with already originated txs (in any connected event)
-		if em.originatedTxs.TotalOf(sender) != 0 {
-			sorted.Pop()
-			continue
-		}
-		// my turn, i.e. try to not include the same tx simultaneously by different validators
-		if !em.isMyTxTurn(tx.Hash(), sender, tx.Nonce(), time.Now(), em.validators, e.Creator(), em.epoch) {
-			sorted.Pop()
-			continue
-		}
-		// check transaction is not outdated
-		if !em.world.TxPool.Has(tx.Hash()) {
+		if !em.isTxOriginable(tx, sender, e.Creator(), rules) {
 			sorted.Pop()
 			continue
 		}
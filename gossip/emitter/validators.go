@@ -32,19 +32,19 @@ func (em *Emitter) recountValidators(validators *pos.Validators) {
 	em.intervals.Confirming = em.expectedEmitIntervals[em.config.Validator.ID]
 	em.intervals.Max = em.config.EmitIntervals.Max
 	// if network just has started, then relax the doublesign protection
-	if time.Since(em.world.GetGenesisTime().Time()) < networkStartPeriod {
+	if em.world.Clock.Now().Sub(em.world.GetGenesisTime().Time()) < networkStartPeriod {
 		em.intervals.Max /= 6
 		em.intervals.DoublesignProtection /= 6
 	}
 }
 
 func (em *Emitter) recheckChallenges() {
-	if time.Since(em.prevRecheckedChallenges) < validatorChallenge/10 {
+	if em.world.Clock.Now().Sub(em.prevRecheckedChallenges) < validatorChallenge/10 {
 		return
 	}
 	em.world.Lock()
 	defer em.world.Unlock()
-	now := time.Now()
+	now := em.world.Clock.Now()
 	if !em.idle() {
 		// give challenges to all the non-spare validators if network isn't idle
 		for _, vid := range em.validators.IDs() {
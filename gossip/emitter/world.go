@@ -3,6 +3,7 @@ package emitter
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/Fantom-foundation/go-opera/evmcore"
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
 	"github.com/Fantom-foundation/go-opera/opera"
 	"github.com/Fantom-foundation/go-opera/valkeystore"
 	"github.com/Fantom-foundation/go-opera/vecmt"
@@ -29,6 +31,7 @@ type (
 		Reader
 
 		Check(e *inter.EventPayload, parents inter.Events) error
+		FastCheck(e *inter.EventPayload, parents inter.Events) error
 		Process(*inter.EventPayload) error
 		Broadcast(*inter.EventPayload)
 		Build(*inter.MutableEventPayload, func()) error
@@ -43,15 +46,29 @@ type (
 	Signer   valkeystore.SignerI
 	TxSigner types.Signer
 
+	// Clock is a source of the current time.
+	// It allows to run emitters in a simulated time
+	Clock interface {
+		Now() time.Time
+	}
+
 	// World is an emitter's environment
 	World struct {
 		External
 		TxPool   TxPool
 		Signer   valkeystore.SignerI
 		TxSigner types.Signer
+		// Clock is the wall clock if not set
+		Clock Clock
 	}
 )
 
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
 type LlrReader interface {
 	GetLowestBlockToDecide() idx.Block
 	GetLastBV(id idx.ValidatorID) *idx.Block
@@ -74,6 +91,7 @@ type Reader interface {
 	GetHeads(idx.Epoch) hash.Events
 	GetGenesisTime() inter.Timestamp
 	GetRules() opera.Rules
+	GetValidatorPubKey(id idx.ValidatorID) validatorpk.PubKey
 }
 
 type TxPool interface {
@@ -90,4 +108,7 @@ type TxPool interface {
 
 	// Count returns the total number of transactions
 	Count() int
+
+	// Locals returns the addresses of the accounts whose transactions are considered local.
+	Locals() []common.Address
 }
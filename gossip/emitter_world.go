@@ -9,6 +9,7 @@ import (
 
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
 	"github.com/Fantom-foundation/go-opera/utils/wgmutex"
 	"github.com/Fantom-foundation/go-opera/valkeystore"
 	"github.com/Fantom-foundation/go-opera/vecmt"
@@ -37,6 +38,11 @@ func (ew *emitterWorldProc) Check(emitted *inter.EventPayload, parents inter.Eve
 	return ew.s.checkers.Validate(emitted, parents.Interfaces())
 }
 
+func (ew *emitterWorldProc) FastCheck(emitted *inter.EventPayload, parents inter.Events) error {
+	// sanity check, excluding signatures
+	return ew.s.checkers.FastValidate(emitted, parents.Interfaces())
+}
+
 func (ew *emitterWorldProc) Process(emitted *inter.EventPayload) error {
 	done := ew.s.procLogger.EventConnectionStarted(emitted, true)
 	defer done()
@@ -68,6 +74,10 @@ func (ew *emitterWorldProc) PeersNum() int {
 	return ew.s.handler.peers.Len()
 }
 
+func (ew *emitterWorldRead) GetValidatorPubKey(id idx.ValidatorID) validatorpk.PubKey {
+	return ew.Store.GetEpochState().ValidatorProfiles[id].PubKey
+}
+
 func (ew *emitterWorldRead) GetHeads(epoch idx.Epoch) hash.Events {
 	return ew.Store.GetHeadsSlice(epoch)
 }
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
@@ -37,6 +38,7 @@ type EthAPIBackend struct {
 	state               *EvmStateReader
 	signer              types.Signer
 	allowUnprotectedTxs bool
+	pending             *pendingState
 }
 
 // ChainConfig returns the active chain configuration.
@@ -106,9 +108,12 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 }
 
 // StateAndHeaderByNumberOrHash returns evm state and block header by block number or block hash, err if not exists.
+// The pending state includes the transactions which are packed into events, but not finalized yet.
 func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *evmcore.EvmHeader, error) {
 	var header *evmcore.EvmHeader
-	if number, ok := blockNrOrHash.Number(); ok && (number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber) {
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		return b.pendingStateAndHeader()
+	} else if number, ok := blockNrOrHash.Number(); ok && number == rpc.LatestBlockNumber {
 		header = &b.state.CurrentBlock().EvmHeader
 	} else if number, ok := blockNrOrHash.Number(); ok {
 		header = b.state.GetHeader(common.Hash{}, uint64(number))
@@ -190,6 +195,61 @@ func (b *EthAPIBackend) GetEvent(ctx context.Context, shortEventID string) (*int
 	return b.svc.store.GetEvent(id), nil
 }
 
+// GetEventByTransaction returns the Lachesis event header which carried the transaction.
+func (b *EthAPIBackend) GetEventByTransaction(ctx context.Context, txHash common.Hash) (*inter.Event, error) {
+	if !b.svc.config.TxIndex {
+		return nil, errors.New("transactions index is disabled (enable TxIndex and re-process the DAG)")
+	}
+
+	position := b.svc.store.evm.GetTxPosition(txHash)
+	if position == nil || position.Event.IsZero() {
+		return nil, nil
+	}
+	return b.svc.store.GetEvent(position.Event), nil
+}
+
+// GetBlockEvents returns the block record with the Atropos and the ordered events of the block.
+func (b *EthAPIBackend) GetBlockEvents(ctx context.Context, number rpc.BlockNumber) (*inter.Block, error) {
+	var n idx.Block
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		n = b.svc.store.GetLatestBlockIndex()
+	} else if number >= 0 {
+		n = idx.Block(number)
+	} else {
+		return nil, errors.New("invalid block number")
+	}
+	return b.svc.store.GetBlock(n), nil
+}
+
+// GetEventsByCreator returns IDs of approximately limit creator's events starting from fromSeq, in seq order,
+// and the seq to continue from, which is 0 if there're no more events.
+// Forks with the same seq are never split between calls, so the limit may be exceeded by forks.
+func (b *EthAPIBackend) GetEventsByCreator(ctx context.Context, epoch rpc.BlockNumber, creator idx.ValidatorID, fromSeq idx.Event, limit int) (hash.Events, idx.Event, error) {
+	requested, err := b.epochWithDefault(ctx, epoch)
+	if err != nil {
+		return nil, 0, err
+	}
+	if requested < b.svc.store.firstEventsEpoch() {
+		return nil, 0, errors.New("events of the epoch are pruned")
+	}
+	if requested < b.svc.store.getCreatorEventsIndexed() {
+		return nil, 0, errors.New("events of the epoch aren't indexed by creator")
+	}
+
+	res := hash.Events{}
+	var lastSeq, next idx.Event
+	b.svc.store.ForEachEventByCreator(requested, creator, fromSeq, func(seq idx.Event, id hash.Event) bool {
+		if len(res) >= limit && seq != lastSeq {
+			next = seq
+			return false
+		}
+		res = append(res, id)
+		lastSeq = seq
+		return true
+	})
+	return res, next, nil
+}
+
 // GetHeads returns IDs of all the epoch events with no descendants.
 // * When epoch is -2 the heads for latest epoch are returned.
 // * When epoch is -1 the heads for latest sealed epoch are returned.
@@ -215,6 +275,36 @@ func (b *EthAPIBackend) GetHeads(ctx context.Context, epoch rpc.BlockNumber) (he
 	return
 }
 
+// GetEpochStats returns events statistics of the epoch.
+// Returns nil if the statistics isn't available, e.g. for epochs which were sealed before the node start or pruned.
+func (b *EthAPIBackend) GetEpochStats(ctx context.Context, epoch rpc.BlockNumber) (*ethapi.EpochStats, error) {
+	requested, err := b.epochWithDefault(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	es := b.svc.store.GetHistoryEpochState(requested)
+	if es == nil {
+		return nil, nil
+	}
+	stats := b.svc.store.GetEpochEventsStats(requested)
+	if stats == nil {
+		return nil, nil
+	}
+	res := &ethapi.EpochStats{
+		Epoch:      requested,
+		Validators: make([]ethapi.ValidatorEventsStats, len(stats.Validators)),
+		MaxFrame:   stats.MaxFrame,
+		Start:      es.EpochStart,
+	}
+	for i, v := range stats.Validators {
+		res.Validators[i] = ethapi.ValidatorEventsStats(v)
+	}
+	if next := b.svc.store.GetHistoryEpochState(requested + 1); next != nil {
+		res.Sealed = next.EpochStart
+	}
+	return res, nil
+}
+
 func (b *EthAPIBackend) epochWithDefault(ctx context.Context, epoch rpc.BlockNumber) (requested idx.Epoch, err error) {
 	current := b.svc.store.GetEpoch()
 
@@ -339,10 +429,32 @@ func (b *EthAPIBackend) SubscribeNewBlockNotify(ch chan<- evmcore.ChainHeadNotif
 	return b.svc.feed.SubscribeNewBlock(ch)
 }
 
+func (b *EthAPIBackend) SubscribeNewEventNotify(ch chan<- ethapi.EventNotify) notify.Subscription {
+	return b.svc.feed.SubscribeNewEvent(ch)
+}
+
+func (b *EthAPIBackend) SubscribeNewEpochNotify(ch chan<- idx.Epoch) notify.Subscription {
+	return b.svc.feed.SubscribeNewEpoch(ch)
+}
+
+func (b *EthAPIBackend) EventsLatency() ethapi.EventsLatency {
+	return b.svc.eventsLatency.Stats()
+}
+
+// GetTxPackers returns creators of events which have packed the transaction.
+// Only events connected since the node start are observed.
+func (b *EthAPIBackend) GetTxPackers(txHash common.Hash) []idx.ValidatorID {
+	return b.svc.packedTxs.Creators(txHash)
+}
+
 func (b *EthAPIBackend) SubscribeNewTxsNotify(ch chan<- evmcore.NewTxsNotify) notify.Subscription {
 	return b.svc.txpool.SubscribeNewTxsNotify(ch)
 }
 
+func (b *EthAPIBackend) SubscribeDroppedTxsNotify(ch chan<- evmcore.DroppedTxsNotify) notify.Subscription {
+	return b.svc.txpool.SubscribeDroppedTxsNotify(ch)
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending, err := b.svc.txpool.Pending(false)
 	if err != nil {
@@ -378,6 +490,9 @@ func (b *EthAPIBackend) GetTransaction(ctx context.Context, txHash common.Hash)
 		tx = b.svc.store.evm.GetTx(txHash)
 	} else {
 		event := b.svc.store.GetEventPayload(position.Event)
+		if event == nil {
+			return nil, 0, 0, fmt.Errorf("event %s of tx %s is not found", position.Event.String(), txHash.String())
+		}
 		if position.EventOffset > uint32(event.Txs().Len()) {
 			return nil, 0, 0, fmt.Errorf("transactions index is corrupted (offset is larger than number of txs in event), event=%s, txid=%s, block=%d, offset=%d, txs_num=%d",
 				position.Event.String(),
@@ -417,6 +532,8 @@ func (b *EthAPIBackend) Progress() ethapi.PeerProgress {
 		CurrentBlockTime: lastBlock.Time,
 		HighestBlock:     highestP2pProgress.LastBlockIdx,
 		HighestEpoch:     highestP2pProgress.Epoch,
+		SyncStage:        b.svc.handler.syncStatus.Stage().String(),
+		BlocksPerSecond:  b.svc.handler.syncSpeed.Rate(),
 	}
 }
 
@@ -452,6 +569,10 @@ func (b *EthAPIBackend) RPCGasCap() uint64 {
 	return b.svc.config.RPCGasCap
 }
 
+func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
+	return b.svc.config.RPCEVMTimeout
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.svc.config.RPCTxFeeCap
 }
@@ -490,6 +611,15 @@ func (b *EthAPIBackend) GetOriginatedFee(ctx context.Context, vid idx.ValidatorI
 	return bs.GetValidatorState(vid, es.Validators).Originated, nil
 }
 
+// GetLastEvent returns the validator's last event of the current epoch.
+func (b *EthAPIBackend) GetLastEvent(ctx context.Context, vid idx.ValidatorID) (*inter.Event, error) {
+	id := b.svc.store.GetLastEvent(b.svc.store.GetEpoch(), vid)
+	if id == nil {
+		return nil, nil
+	}
+	return b.svc.store.GetEvent(*id), nil
+}
+
 func (b *EthAPIBackend) GetDowntime(ctx context.Context, vid idx.ValidatorID) (idx.Block, inter.Timestamp, error) {
 	// Note: loads bs and es atomically to avoid a race condition
 	bs, es := b.svc.store.GetBlockEpochState()
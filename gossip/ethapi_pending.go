@@ -0,0 +1,100 @@
+package gossip
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/opera"
+)
+
+// pendingState caches the pending pseudo-block. It's rebuilt at most once per a change of the pending txs or a new block
+type pendingState struct {
+	mu     sync.Mutex
+	key    pendingStateKey
+	state  *state.StateDB
+	header *evmcore.EvmHeader
+}
+
+type pendingStateKey struct {
+	latest common.Hash
+	txs    uint64
+}
+
+// pendingStateAndHeader returns a copy of the cached pending state, the cache is rebuilt if the pending txs or
+// the latest block are changed. The pending state equals to the latest state if the pseudo-block cannot be built
+// within the RPC EVM timeout.
+func (b *EthAPIBackend) pendingStateAndHeader() (*state.StateDB, *evmcore.EvmHeader, error) {
+	latest := b.state.CurrentBlock()
+	key := pendingStateKey{
+		latest: latest.Hash,
+		txs:    b.svc.pendingEventTxs.Version(),
+	}
+
+	b.pending.mu.Lock()
+	defer b.pending.mu.Unlock()
+	if b.pending.state == nil || b.pending.key != key {
+		// the build isn't bound to the caller's context, because its result is shared by all the callers
+		ctx := context.Background()
+		if timeout := b.RPCEVMTimeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		stateDb, header, err := b.buildPendingStateAndHeader(ctx, latest)
+		if err != nil {
+			log.Debug("Pending state is replaced by the latest state", "err", err)
+			var stateErr error
+			stateDb, stateErr = b.svc.store.evm.StateDB(hash.Hash(latest.Root))
+			if stateErr != nil {
+				return nil, nil, stateErr
+			}
+			header = &latest.EvmHeader
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				// don't cache the fallback if the building has timed out, it may be built by a next call
+				h := *header
+				return stateDb, &h, nil
+			}
+		}
+		b.pending.key, b.pending.state, b.pending.header = key, stateDb, header
+	}
+	header := *b.pending.header
+	return b.pending.state.Copy(), &header, nil
+}
+
+// buildPendingStateAndHeader assembles a pseudo-block on top of the latest block from
+// the transactions which are packed into events, but not finalized yet.
+// Transactions which cannot be executed are skipped, similarly to the blocks processing.
+func (b *EthAPIBackend) buildPendingStateAndHeader(ctx context.Context, latest *evmcore.EvmBlock) (*state.StateDB, *evmcore.EvmHeader, error) {
+	stateDb, err := b.svc.store.evm.StateDB(hash.Hash(latest.Root))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := latest.EvmHeader
+	header.Number = new(big.Int).Add(latest.Number, common.Big1)
+	header.ParentHash = latest.Hash
+	header.Hash = common.Hash{}
+	header.Time = inter.MaxTimestamp(inter.Timestamp(time.Now().UnixNano()), latest.Time+1)
+	header.GasLimit = math.MaxUint64
+	header.GasUsed = 0
+
+	block := evmcore.NewEvmBlock(&header, b.svc.pendingEventTxs.Txs())
+	processor := evmcore.NewStateProcessor(b.ChainConfig(), b.state)
+	_, _, _, err = processor.ProcessWithContext(ctx, block, stateDb, opera.DefaultVMConfig, &block.GasUsed, func(*types.Log, *state.StateDB) {})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stateDb, block.Header(), nil
+}
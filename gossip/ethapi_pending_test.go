@@ -0,0 +1,58 @@
+package gossip
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestPendingStateCache(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	_, err := env.ApplyTxs(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	require.NoError(err)
+	latest := env.EthAPI.state.CurrentBlock()
+	pending := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+
+	state1, header1, err := env.EthAPI.StateAndHeaderByNumberOrHash(context.Background(), pending)
+	require.NoError(err)
+	require.Equal(new(big.Int).Add(latest.Number, common.Big1), header1.Number)
+	require.Equal(latest.Hash, header1.ParentHash)
+
+	// the cached state is shared by copies
+	addr := common.Address{0xff}
+	state1.AddBalance(addr, big.NewInt(1))
+	state2, header2, err := env.EthAPI.StateAndHeaderByNumberOrHash(context.Background(), pending)
+	require.NoError(err)
+	require.Equal(header1, header2)
+	require.Zero(state2.GetBalance(addr).Sign())
+
+	// the caller's context doesn't affect the shared pending state
+	env.EthAPI.pending.state = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, header3, err := env.EthAPI.StateAndHeaderByNumberOrHash(ctx, pending)
+	require.NoError(err)
+	require.Equal(header1, header3)
+
+	// pending state equals to the latest state if it cannot be built in time, the fallback isn't cached
+	env.EthAPI.pending.state = nil
+	env.config.RPCEVMTimeout = time.Nanosecond
+	_, header4, err := env.EthAPI.StateAndHeaderByNumberOrHash(context.Background(), pending)
+	require.NoError(err)
+	require.Equal(latest.Number, header4.Number)
+	require.Equal(latest.Hash, header4.Hash)
+	require.Nil(env.EthAPI.pending.state)
+}
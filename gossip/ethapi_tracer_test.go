@@ -0,0 +1,64 @@
+package gossip
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/ethapi"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestTraceTransaction(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	// txs of the same sender depend on each other, so they are traceable only if the preceding txs are replayed
+	receipts, err := env.ApplyTxs(sameEpoch,
+		env.Transfer(1, 2, utils.ToFtm(1)),
+		env.Transfer(1, 3, utils.ToFtm(2)),
+		env.Transfer(1, 2, utils.ToFtm(3)),
+	)
+	require.NoError(err)
+
+	api := ethapi.NewPrivateDebugAPI(env.EthAPI)
+	ctx := context.Background()
+	replayed := false
+	for _, r := range receipts {
+		replayed = replayed || r.TransactionIndex > 0
+
+		res, err := api.TraceTransaction(ctx, r.TxHash, nil)
+		require.NoError(err)
+		logs, ok := res.(*ethapi.ExecutionResult)
+		require.True(ok)
+		require.Equal(r.GasUsed, logs.Gas)
+		require.Equal(r.Status == types.ReceiptStatusFailed, logs.Failed)
+
+		tracer := "callTracer"
+		res, err = api.TraceTransaction(ctx, r.TxHash, &ethapi.TraceConfig{Tracer: &tracer})
+		require.NoError(err)
+		raw, ok := res.(json.RawMessage)
+		require.True(ok)
+		var call struct {
+			Type string `json:"type"`
+			To   string `json:"to"`
+		}
+		require.NoError(json.Unmarshal(raw, &call))
+		require.Equal("CALL", call.Type)
+		tx, _, _, err := env.EthAPI.GetTransaction(ctx, r.TxHash)
+		require.NoError(err)
+		require.Equal(strings.ToLower(tx.To().Hex()), call.To)
+	}
+	require.True(replayed, "no tx with preceding txs in its block")
+
+	_, err = api.TraceTransaction(ctx, receipts[0].TxHash, &ethapi.TraceConfig{Tracer: new(string)})
+	require.Error(err)
+}
@@ -0,0 +1,66 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/metrics"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/Fantom-foundation/go-opera/ethapi"
+)
+
+var eventConfirmationTimer = metrics.GetOrRegisterTimer("chain/event/confirmation", nil)
+
+// maxTrackedEvents limits the number of connected events waiting for confirmation
+const maxTrackedEvents = 50000
+
+// eventsLatency tracks the time between events connection and their confirmation in a block
+type eventsLatency struct {
+	connected *lru.Cache // event ID -> time.Time of connection
+
+	mu    sync.Mutex
+	stats ethapi.EventsLatency
+	total time.Duration
+}
+
+func newEventsLatency() *eventsLatency {
+	connected, _ := lru.New(maxTrackedEvents)
+	return &eventsLatency{
+		connected: connected,
+	}
+}
+
+// Connected is called when the event is being connected to the DAG
+func (l *eventsLatency) Connected(id hash.Event, now time.Time) {
+	l.connected.Add(id, now)
+}
+
+// Confirmed is called when the event is confirmed in a block
+func (l *eventsLatency) Confirmed(id hash.Event, now time.Time) {
+	v, ok := l.connected.Get(id)
+	if !ok {
+		return
+	}
+	l.connected.Remove(id)
+	latency := now.Sub(v.(time.Time))
+	eventConfirmationTimer.Update(latency)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Confirmed++
+	l.stats.Last = latency
+	if latency > l.stats.Max {
+		l.stats.Max = latency
+	}
+	l.total += latency
+	l.stats.Mean = l.total / time.Duration(l.stats.Confirmed)
+}
+
+// Stats returns the summary of the tracked latencies
+func (l *eventsLatency) Stats() ethapi.EventsLatency {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
@@ -5,7 +5,10 @@ package evmstore
 */
 
 import (
+	"bytes"
+
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -93,3 +96,29 @@ func (s *Store) GetReceipts(n idx.Block, signer types.Signer, hash common.Hash,
 
 	return receipts
 }
+
+// PruneReceipts deletes receipts of all the blocks before the specified one.
+func (s *Store) PruneReceipts(before idx.Block) error {
+	it := s.table.Receipts.NewIterator(nil, nil)
+	defer it.Release()
+	batch := s.table.Receipts.NewBatch()
+	defer batch.Reset()
+	for it.Next() {
+		if bytes.Compare(it.Key(), before.Bytes()) >= 0 {
+			break
+		}
+		err := batch.Delete(it.Key())
+		if err != nil {
+			return err
+		}
+		s.cache.Receipts.Remove(idx.BytesToBlock(it.Key()))
+		if batch.ValueSize() > kvdb.IdealBatchSize {
+			err := batch.Write()
+			if err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	return batch.Write()
+}
@@ -55,12 +55,15 @@ type Config struct {
 	IndexedLogsBlockRangeLimit idx.Block
 	// Block range limit for logs search (unindexed).
 	UnindexedLogsBlockRangeLimit idx.Block
+	// Limit of logs returned by a single logs search. 0 means no limit
+	MaxLogs int
 }
 
 func DefaultConfig() Config {
 	return Config{
 		IndexedLogsBlockRangeLimit:   999999999999999999,
 		UnindexedLogsBlockRangeLimit: 100,
+		MaxLogs:                      0,
 	}
 }
 
@@ -101,7 +101,19 @@ func newFilter(backend Backend, cfg Config, addresses []common.Address, topics [
 
 // Logs searches the blockchain for matching log entries, returning all from the
 // first block that contains matches, updating the start of the filter accordingly.
+// The search fails if the number of found logs exceeds Config.MaxLogs.
 func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
+	logs, err := f.logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if f.tooManyLogs(logs) {
+		return nil, errTooManyLogs(f.config.MaxLogs)
+	}
+	return logs, nil
+}
+
+func (f *Filter) logs(ctx context.Context) ([]*types.Log, error) {
 	// If we're doing singleton block filtering, execute and return
 	if f.block != common.Hash(hash.Zero) {
 		header, err := f.backend.HeaderByHash(ctx, f.block)
@@ -154,10 +166,18 @@ func (f *Filter) indexedLogs(ctx context.Context, begin, end idx.Block) ([]*type
 	pattern[0] = addresses
 	pattern = append(pattern, f.topics...)
 
-	logs, err := f.backend.EvmLogIndex().FindInBlocks(ctx, begin, end, pattern)
+	var logs []*types.Log
+	err := f.backend.EvmLogIndex().ForEachInBlocks(ctx, begin, end, pattern, func(l *types.Log) bool {
+		logs = append(logs, l)
+		// stop the search early, Logs fails anyway
+		return !f.tooManyLogs(logs)
+	})
 	if err != nil {
 		return nil, err
 	}
+	if f.tooManyLogs(logs) {
+		return nil, errTooManyLogs(f.config.MaxLogs)
+	}
 
 	for _, l := range logs {
 		pos := f.backend.GetTxPosition(l.TxHash)
@@ -197,10 +217,21 @@ func (f *Filter) unindexedLogs(ctx context.Context, begin, end idx.Block) (logs
 			return
 		}
 		logs = append(logs, found...)
+		if f.tooManyLogs(logs) {
+			return nil, errTooManyLogs(f.config.MaxLogs)
+		}
 	}
 	return
 }
 
+func (f *Filter) tooManyLogs(logs []*types.Log) bool {
+	return f.config.MaxLogs != 0 && len(logs) > f.config.MaxLogs
+}
+
+func errTooManyLogs(limit int) error {
+	return fmt.Errorf("too many logs, the limit is %d, narrow the blocks range", limit)
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(ctx context.Context, header common.Hash) ([]*types.Log, error) {
 	// Get the logs of the block
@@ -264,4 +264,21 @@ func TestFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 
+	limited := testConfig()
+	limited.MaxLogs = 3
+	filter = NewRangeFilter(backend, limited, 0, -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3}})
+	logs, err = filter.Logs(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if len(logs) != 3 {
+		t.Error("expected 3 log, got", len(logs))
+	}
+
+	filter = NewRangeFilter(backend, limited, 0, -1, []common.Address{addr}, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	logs, err = filter.Logs(context.Background())
+	if err == nil {
+		t.Error("expected too many logs error, got", len(logs))
+	}
+
 }
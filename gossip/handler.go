@@ -18,7 +18,9 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	notify "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 
@@ -61,6 +63,28 @@ const (
 	txChanSize = 4096
 )
 
+var (
+	rateLimitedEventsMeter = metrics.GetOrRegisterMeter("gossip/ratelimited/events", nil)
+	rateLimitedTxsMeter    = metrics.GetOrRegisterMeter("gossip/ratelimited/txs", nil)
+	rateLimitedBytesMeter  = metrics.GetOrRegisterMeter("gossip/ratelimited/bytes", nil)
+
+	rejectedEventsMeter    = metrics.GetOrRegisterMeter("gossip/rejected/events", nil) // all rejections, see also rejectedCauseMeters
+	misbehaviourPeersMeter = metrics.GetOrRegisterMeter("gossip/dropped/misbehaviour", nil)
+	penalizedPeersMeter    = metrics.GetOrRegisterMeter("gossip/dropped/penalty", nil)
+	futureEventsMeter      = metrics.GetOrRegisterMeter("gossip/ignored/future_events", nil)
+	throttledMsgsMeter     = metrics.GetOrRegisterMeter("gossip/ignored/throttled", nil)
+	bannedPeersMeter       = metrics.GetOrRegisterMeter("gossip/rejected/banned_peers", nil)
+)
+
+const (
+	// penalty points per an item received from a peer
+	duplicatePenalty = 1
+	rejectedPenalty  = 10
+)
+
+// errRateLimited is a penalty reason for a message dropped due to the peer's rate limits
+var errRateLimited = errors.New("peer rate limit exceeded")
+
 func errResp(code errCode, format string, v ...interface{}) error {
 	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
 }
@@ -100,6 +124,10 @@ type handlerConfig struct {
 	checkers *eventcheck.Checkers
 	s        *Store
 	process  processCallback
+	// localID returns the ID of the local node, validator proofs are bound to it
+	localID func() enode.ID
+	// signValidatorProof signs a validator proof by a local validator of the current epoch, if any
+	signValidatorProof func(digest []byte) (idx.ValidatorID, []byte, error)
 }
 
 type snapsyncEpochUpd struct {
@@ -128,6 +156,7 @@ type handler struct {
 	config    Config
 
 	syncStatus syncStatus
+	syncSpeed  syncSpeed
 
 	txpool   TxPool
 	maxPeers int
@@ -165,6 +194,12 @@ type handler struct {
 	store    *Store
 	engineMu sync.Locker
 
+	penalties   map[enode.ID]*peerPenalty
+	penaltiesMu sync.Mutex
+
+	localID            func() enode.ID
+	signValidatorProof func(digest []byte) (idx.ValidatorID, []byte, error)
+
 	notifier             dagNotifier
 	emittedEventsCh      chan *inter.EventPayload
 	emittedEventsSub     notify.Subscription
@@ -202,6 +237,7 @@ func newHandler(
 	// Create the protocol manager with the base fields
 	h := &handler{
 		NetworkID:            c.s.GetRules().NetworkID,
+		penalties:            make(map[enode.ID]*peerPenalty),
 		config:               c.config,
 		notifier:             c.notifier,
 		txpool:               c.txpool,
@@ -209,6 +245,8 @@ func newHandler(
 		store:                c.s,
 		process:              c.process,
 		checkers:             c.checkers,
+		localID:              c.localID,
+		signValidatorProof:   c.signValidatorProof,
 		peers:                newPeerSet(),
 		engineMu:             c.engineMu,
 		txsyncCh:             make(chan *txsync),
@@ -372,7 +410,7 @@ func newHandler(
 			return llrs.LowestEpochToDecide
 		},
 		MaxEpochToFetch: func() idx.Epoch {
-			return h.store.GetLlrState().LowestEpochToDecide + 10000
+			return h.store.GetLlrState().LowestEpochToDecide + h.config.Protocol.EpStreamLeecher.MaxEpochsAhead
 		},
 		IsProcessed: h.store.HasHistoryBlockEpochState,
 		RequestChunk: func(peer string, r epstream.Request) error {
@@ -403,12 +441,110 @@ func newHandler(
 func (h *handler) peerMisbehaviour(peer string, err error) bool {
 	if eventcheck.IsBan(err) {
 		log.Warn("Dropping peer due to a misbehaviour", "peer", peer, "err", err)
-		h.removePeer(peer)
+		misbehaviourPeersMeter.Mark(1)
+		h.banPeer(peer)
 		return true
 	}
+	return h.penalizePeer(peer, err)
+}
+
+// peerPenaltyOf returns the penalty points for an item which was received from a peer and processed with the error.
+// Spilled and already known events are expected during normal gossip and aren't penalized.
+func peerPenaltyOf(err error) uint32 {
+	switch err {
+	case nil, eventcheck.ErrSpilledEvent, eventcheck.ErrDuplicateEvent, eventcheck.ErrAlreadyConnectedEvent:
+		return 0
+	case errRateLimited:
+		return duplicatePenalty
+	}
+	return rejectedPenalty
+}
+
+// peerPenalty is a penalty of a peer within PeerPenaltyPeriod, it's kept in memory across reconnections
+type peerPenalty struct {
+	penalty uint32
+	start   time.Time
+}
+
+// penalizePeer adds the penalty to the peer's reputation record.
+// The peer's broadcasts are throttled if the penalty is high, and the peer is dropped and banned if the penalty is too high.
+func (h *handler) penalizePeer(id string, err error) bool {
+	points := peerPenaltyOf(err)
+	if points == 0 || h.config.Protocol.MaxPeerPenalty == 0 {
+		return false
+	}
+	peer := h.peers.Peer(id)
+	if peer == nil {
+		return false
+	}
+
+	h.penaltiesMu.Lock()
+	defer h.penaltiesMu.Unlock()
+
+	now := time.Now()
+	period := h.config.Protocol.PeerPenaltyPeriod
+	record := h.penalties[peer.ID()]
+	if record == nil || now.Sub(record.start) >= period {
+		// forget the expired records
+		for pid, r := range h.penalties {
+			if now.Sub(r.start) >= period {
+				delete(h.penalties, pid)
+			}
+		}
+		record = &peerPenalty{start: now}
+		h.penalties[peer.ID()] = record
+	}
+	record.penalty += points
+
+	if record.penalty > h.config.Protocol.MaxPeerPenalty {
+		delete(h.penalties, peer.ID())
+		h.store.SetPeerBan(peer.ID(), inter.Timestamp(now.Add(h.config.Protocol.PeerBanPeriod).UnixNano()))
+		log.Warn("Dropping peer due to too many rejected items", "peer", id, "err", err)
+		penalizedPeersMeter.Mark(1)
+		h.removePeer(id)
+		return true
+	}
+	if record.penalty > h.config.Protocol.PeerThrottlePenalty {
+		peer.ThrottleUntil(record.start.Add(period))
+	}
 	return false
 }
 
+// banPeer drops the peer and rejects its connections for PeerBanPeriod
+func (h *handler) banPeer(id string) {
+	if peer := h.peers.Peer(id); peer != nil {
+		h.penaltiesMu.Lock()
+		h.store.SetPeerBan(peer.ID(), inter.Timestamp(time.Now().Add(h.config.Protocol.PeerBanPeriod).UnixNano()))
+		h.penaltiesMu.Unlock()
+	}
+	h.removePeer(id)
+}
+
+// restorePeerPenalty applies the reputation record of a connecting peer, returns false if the peer is banned
+func (h *handler) restorePeerPenalty(p *peer) bool {
+	h.penaltiesMu.Lock()
+	defer h.penaltiesMu.Unlock()
+
+	now := time.Now()
+	if inter.Timestamp(now.UnixNano()) < h.store.GetPeerBan(p.ID()) {
+		return false
+	}
+	record := h.penalties[p.ID()]
+	if record == nil {
+		return true
+	}
+	end := record.start.Add(h.config.Protocol.PeerPenaltyPeriod)
+	if now.Before(end) && record.penalty > h.config.Protocol.PeerThrottlePenalty {
+		p.ThrottleUntil(end)
+	}
+	return true
+}
+
+// isBroadcastMsg returns true for the messages which a peer sends unsolicited
+func isBroadcastMsg(code uint64) bool {
+	return code == EventsMsg || code == NewEventIDsMsg || code == EvmTxsMsg || code == NewEvmTxHashesMsg
+}
+
 func (h *handler) makeDagProcessor(checkers *eventcheck.Checkers) *dagprocessor.Processor {
 	// checkers
 	lightCheck := func(e dag.Event) error {
@@ -472,9 +608,15 @@ func (h *handler) makeDagProcessor(checkers *eventcheck.Checkers) *dagprocessor.
 				return nil
 			},
 			Released: func(e dag.Event, peer string, err error) {
+				if err != nil {
+					markRejectedEvent(err)
+				}
 				if eventcheck.IsBan(err) {
 					log.Warn("Incoming event rejected", "event", e.ID().String(), "creator", e.Creator(), "err", err)
-					h.removePeer(peer)
+					misbehaviourPeersMeter.Mark(1)
+					h.banPeer(peer)
+				} else {
+					h.penalizePeer(peer, err)
 				}
 			},
 
@@ -789,7 +931,17 @@ func (h *handler) handle(p *peer) error {
 	if h.peers.Len() >= h.maxPeers && !p.Peer.Info().Network.Trusted {
 		return p2p.DiscTooManyPeers
 	}
+	// Peers which can't prove to be validators may occupy only the slots which aren't reserved for validators
+	challenged := p.RunningCap(ProtocolName, []uint{FTM64})
+	if !challenged && h.nonValidatorPeers() >= h.nonValidatorPeerSlots() && !p.Peer.Info().Network.Trusted {
+		return p2p.DiscTooManyPeers
+	}
+	if !h.restorePeerPenalty(p) && !p.Peer.Info().Network.Trusted {
+		bannedPeersMeter.Mark(1)
+		return p2p.DiscUselessPeer
+	}
 	p.Log().Debug("Peer connected", "name", p.Name())
+	p.rateLimits = newPeerRateLimits(h.config.Protocol.PeerRateLimit)
 
 	// Register the peer locally
 	if err := h.peers.RegisterPeer(p, snap); err != nil {
@@ -800,7 +952,7 @@ func (h *handler) handle(p *peer) error {
 		p.Log().Warn("Leecher peer registration failed", "err", err)
 		return err
 	}
-	if p.RunningCap(ProtocolName, []uint{FTM63}) {
+	if p.RunningCap(ProtocolName, []uint{FTM63, FTM64}) {
 		if err := h.epLeecher.RegisterPeer(p.id); err != nil {
 			p.Log().Warn("Leecher peer registration failed", "err", err)
 			return err
@@ -822,6 +974,13 @@ func (h *handler) handle(p *peer) error {
 	}
 	defer h.unregisterPeer(p.id)
 
+	if challenged {
+		if err := h.challengeValidator(p); err != nil {
+			p.Log().Debug("Validator challenge failed", "err", err)
+			return err
+		}
+	}
+
 	// Propagate existing transactions. new transactions appearing
 	// after this will be sent via broadcasts.
 	h.syncTransactions(p, h.txpool.SampleHashes(h.config.Protocol.MaxInitialTxHashesSend))
@@ -892,16 +1051,23 @@ func (h *handler) handleEventHashes(p *peer, announces hash.Events) {
 	for _, id := range announces {
 		p.MarkEvent(id)
 	}
-	// filter too high IDs
+	// filter too high IDs and IDs of sealed epochs
 	notTooHigh := make(hash.Events, 0, len(announces))
 	sessionCfg := h.config.Protocol.DagStreamLeecher.Session
+	myEpoch := h.store.GetEpoch()
+	tooHigh := false
 	for _, id := range announces {
+		if id.Epoch() < myEpoch {
+			continue
+		}
 		maxLamport := h.store.GetHighestLamport() + idx.Lamport(sessionCfg.DefaultChunkItemsNum+1)*idx.Lamport(sessionCfg.ParallelChunksDownload)
 		if id.Lamport() <= maxLamport {
 			notTooHigh = append(notTooHigh, id)
+		} else {
+			tooHigh = true
 		}
 	}
-	if len(announces) != len(notTooHigh) {
+	if tooHigh {
 		h.dagLeecher.ForceSyncing()
 	}
 	if len(notTooHigh) == 0 {
@@ -922,18 +1088,27 @@ func (h *handler) handleEvents(p *peer, events dag.Events, ordered bool) {
 	// filter too high events
 	notTooHigh := make(dag.Events, 0, len(events))
 	sessionCfg := h.config.Protocol.DagStreamLeecher.Session
+	tooHigh := false
 	for _, e := range events {
 		maxLamport := h.store.GetHighestLamport() + idx.Lamport(sessionCfg.DefaultChunkItemsNum+1)*idx.Lamport(sessionCfg.ParallelChunksDownload)
-		if e.Lamport() <= maxLamport {
-			notTooHigh = append(notTooHigh, e)
+		if e.Lamport() > maxLamport {
+			tooHigh = true
+			continue
+		}
+		if h.isEventTooFarAhead(e) {
+			futureEventsMeter.Mark(1)
+			h.Log.Debug("Ignoring event with claimed time too far ahead", "event", e.ID(), "creator", e.Creator(), "peer", p.id)
+			continue
 		}
+		notTooHigh = append(notTooHigh, e)
 	}
-	if len(events) != len(notTooHigh) {
+	if tooHigh {
 		h.dagLeecher.ForceSyncing()
 	}
 	if len(notTooHigh) == 0 {
 		return
 	}
+	h.requestSelfParentGaps(p, notTooHigh)
 	// Schedule all the events for connection
 	peer := *p
 	now := time.Now()
@@ -946,6 +1121,58 @@ func (h *handler) handleEvents(p *peer, events dag.Events, ordered bool) {
 	_ = h.dagProcessor.Enqueue(peer.id, notTooHigh, ordered, notifyAnnounces, nil)
 }
 
+// isEventTooFarAhead checks the event's claimed time against the local clock.
+// It isn't an event validity rule, the event may be accepted later when the local clock catches up.
+func (h *handler) isEventTooFarAhead(e dag.Event) bool {
+	if h.config.Protocol.MaxEventTimeAhead == 0 {
+		return false
+	}
+	ie, ok := e.(inter.EventI)
+	if !ok {
+		return false
+	}
+	return ie.CreationTime().Time().After(time.Now().Add(h.config.Protocol.MaxEventTimeAhead))
+}
+
+// requestSelfParentGaps requests IDs of missing events between the last connected event of a creator
+// and an incoming event, instead of chasing the self-parents one by one.
+func (h *handler) requestSelfParentGaps(p *peer, events dag.Events) {
+	if !p.RunningCap(ProtocolName, []uint{FTM64}) {
+		return
+	}
+	myEpoch := h.store.GetEpoch()
+	inBatch := make(map[hash.Event]bool, len(events))
+	for _, e := range events {
+		inBatch[e.ID()] = true
+	}
+	requested := make(map[idx.ValidatorID]bool)
+	for _, e := range events {
+		sp := e.SelfParent()
+		if e.Epoch() != myEpoch || sp == nil || requested[e.Creator()] {
+			continue
+		}
+		if inBatch[*sp] || h.dagProcessor.IsBuffered(*sp) || h.store.HasEvent(*sp) {
+			continue
+		}
+		lastSeq := idx.Event(0)
+		if last := h.store.GetLastEvent(myEpoch, e.Creator()); last != nil {
+			if lastEvent := h.store.GetEvent(*last); lastEvent != nil {
+				lastSeq = lastEvent.Seq()
+			}
+		}
+		// a single missing self-parent is fetched by the DAG processor
+		if e.Seq() <= lastSeq+2 {
+			continue
+		}
+		from, to := lastSeq+1, e.Seq()-1
+		if to-from >= hardLimitItems {
+			from = to - hardLimitItems + 1
+		}
+		requested[e.Creator()] = true
+		_ = p.RequestCreatorEventIDs(myEpoch, e.Creator(), from, to)
+	}
+}
+
 // handleMsg is invoked whenever an inbound message is received from a remote
 // peer. The remote connection is torn down upon returning any error.
 func (h *handler) handleMsg(p *peer) error {
@@ -958,6 +1185,17 @@ func (h *handler) handleMsg(p *peer) error {
 		return errResp(ErrMsgTooLarge, "%v > %v", msg.Size, protocolMaxMsgSize)
 	}
 	defer msg.Discard()
+	if p.Throttled() && isBroadcastMsg(msg.Code) {
+		throttledMsgsMeter.Mark(1)
+		return nil
+	}
+	if isBroadcastMsg(msg.Code) && !allowRate(p.rateLimits.bytes, int(msg.Size)) {
+		rateLimitedBytesMeter.Mark(int64(msg.Size))
+		if h.penalizePeer(p.id, errRateLimited) {
+			return errRateLimited
+		}
+		return nil
+	}
 	// Acquire semaphore for serialized messages
 	eventsSizeEst := dag.Metric{
 		Num:  1,
@@ -1000,6 +1238,13 @@ func (h *handler) handleMsg(p *peer) error {
 		if err := checkLenLimits(len(txs), txs); err != nil {
 			return err
 		}
+		if !allowRate(p.rateLimits.txs, len(txs)) {
+			rateLimitedTxsMeter.Mark(int64(len(txs)))
+			if h.penalizePeer(p.id, errRateLimited) {
+				return errRateLimited
+			}
+			break
+		}
 		txids := make([]interface{}, txs.Len())
 		for i, tx := range txs {
 			txids[i] = tx.Hash()
@@ -1055,6 +1300,13 @@ func (h *handler) handleMsg(p *peer) error {
 		if err := checkLenLimits(len(events), events); err != nil {
 			return err
 		}
+		if !allowRate(p.rateLimits.events, len(events)) {
+			rateLimitedEventsMeter.Mark(int64(len(events)))
+			if h.penalizePeer(p.id, errRateLimited) {
+				return errRateLimited
+			}
+			break
+		}
 		_ = h.dagFetcher.NotifyReceived(eventIDsToInterfaces(events.IDs()))
 		h.handleEvents(p, events.Bases(), events.Len() > 1)
 
@@ -1100,6 +1352,48 @@ func (h *handler) handleMsg(p *peer) error {
 			p.EnqueueSendEventsRLP(rawEvents, ids, p.queue)
 		}
 
+	case msg.Code == GetCreatorEventIDsMsg:
+		var request creatorEventIDsRequest
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		if request.From == 0 || request.To < request.From {
+			return errResp(ErrDecode, "%v: invalid seq range %d-%d", msg, request.From, request.To)
+		}
+		if request.To-request.From >= hardLimitItems {
+			return errResp(ErrMsgTooLarge, "%v", msg)
+		}
+		ids := h.store.GetEventsByCreator(request.Epoch, request.Creator, request.From, request.To)
+		if len(ids) > hardLimitItems {
+			// may happen only in a case of forks
+			ids = ids[:hardLimitItems]
+		}
+		if len(ids) != 0 {
+			p.AsyncSendEventIDs(ids, p.queue)
+		}
+
+	case msg.Code == GetValidatorProofMsg:
+		var challenge validatorChallenge
+		if err := msg.Decode(&challenge); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		if err := h.proveValidator(p, challenge); err != nil {
+			return err
+		}
+
+	case msg.Code == ValidatorProofMsg:
+		var proof validatorProof
+		if err := msg.Decode(&proof); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		keep, err := h.onValidatorProof(p, proof)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			return p2p.DiscTooManyPeers
+		}
+
 	case msg.Code == RequestEventsStream:
 		var request dagstream.Request
 		if err := msg.Decode(&request); err != nil {
@@ -1341,6 +1635,9 @@ func (h *handler) BroadcastEvent(event *inter.EventPayload, passed time.Duration
 	}
 
 	fullRecipients := h.decideBroadcastAggressiveness(event.Size(), passed, len(peers))
+	if maxFull := h.config.Protocol.MaxFullEventRecipients; maxFull != 0 && fullRecipients > maxFull {
+		fullRecipients = maxFull
+	}
 
 	// Broadcast of full event to a subset of peers
 	fullBroadcast := peers[:fullRecipients]
@@ -1356,6 +1653,56 @@ func (h *handler) BroadcastEvent(event *inter.EventPayload, passed time.Duration
 	return len(peers)
 }
 
+// BroadcastEvents propagates a bundle of events, sending a single message per peer.
+func (h *handler) BroadcastEvents(events inter.EventPayloads, passed time.Duration) {
+	if passed < 0 {
+		passed = 0
+	}
+	fullSet := make(map[*peer]inter.EventPayloads)
+	hashSet := make(map[*peer]hash.Events)
+	for _, event := range events {
+		peers := h.peers.PeersWithoutEvent(event.ID())
+		fullRecipients := h.decideBroadcastAggressiveness(event.Size(), passed, len(peers))
+		if maxFull := h.config.Protocol.MaxFullEventRecipients; maxFull != 0 && fullRecipients > maxFull {
+			fullRecipients = maxFull
+		}
+		for i, peer := range peers {
+			if i < fullRecipients {
+				fullSet[peer] = append(fullSet[peer], event)
+			} else {
+				hashSet[peer] = append(hashSet[peer], event.ID())
+			}
+		}
+	}
+	for peer, bundle := range fullSet {
+		for _, chunk := range splitEventsBySize(bundle, protocolMaxMsgSize*2/3) {
+			peer.AsyncSendEvents(chunk, peer.queue)
+		}
+	}
+	for peer, ids := range hashSet {
+		peer.AsyncSendEventIDs(ids, peer.queue)
+	}
+	log.Trace("Broadcast events bundle", "num", len(events), "fullRecipients", len(fullSet), "hashRecipients", len(hashSet))
+}
+
+// splitEventsBySize splits the events into chunks with a total size not greater than limit.
+// An event which is larger than the limit forms a chunk on its own.
+func splitEventsBySize(events inter.EventPayloads, limit int) []inter.EventPayloads {
+	chunks := make([]inter.EventPayloads, 0, 1)
+	start, size := 0, 0
+	for i, e := range events {
+		if i > start && size+e.Size() > limit {
+			chunks = append(chunks, events[start:i])
+			start, size = i, 0
+		}
+		size += e.Size()
+	}
+	if start < len(events) {
+		chunks = append(chunks, events[start:])
+	}
+	return chunks
+}
+
 // BroadcastTxs will propagate a batch of transactions to all peers which are not known to
 // already have the given transaction.
 func (h *handler) BroadcastTxs(txs types.Transactions) {
@@ -1395,7 +1742,22 @@ func (h *handler) emittedBroadcastLoop() {
 	for {
 		select {
 		case emitted := <-h.emittedEventsCh:
-			h.BroadcastEvent(emitted, 0)
+			// bundle the events emitted in a burst, e.g. after a pause
+			bundle := inter.EventPayloads{emitted}
+		drain:
+			for len(bundle) < softLimitItems {
+				select {
+				case e := <-h.emittedEventsCh:
+					bundle = append(bundle, e)
+				default:
+					break drain
+				}
+			}
+			if len(bundle) == 1 {
+				h.BroadcastEvent(emitted, 0)
+			} else {
+				h.BroadcastEvents(bundle, 0)
+			}
 		// Err() channel will be closed when unsubscribing.
 		case <-h.emittedEventsSub.Err():
 			return
@@ -1441,6 +1803,14 @@ func (h *handler) onNewEpochLoop() {
 				}
 			}
 			h.dagLeecher.OnNewEpoch(myEpoch)
+			// validators are changed, so the peers have to prove to be validators of the new epoch
+			for _, peer := range h.peers.List() {
+				if peer.RunningCap(ProtocolName, []uint{FTM64}) {
+					if err := h.challengeValidator(peer); err != nil {
+						peer.Log().Debug("Validator challenge failed", "err", err)
+					}
+				}
+			}
 		// Err() channel will be closed when unsubscribing.
 		case <-h.newEpochsSub.Err():
 			return
@@ -0,0 +1,90 @@
+package gossip
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/Fantom-foundation/go-opera/eventcheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/basiccheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/gaspowercheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/heavycheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/parentscheck"
+)
+
+var (
+	// rejectedCauseMeters count rejected events per a sentinel validation error
+	rejectedCauseMeters = newRejectedCauseMeters(
+		eventcheck.ErrAlreadyConnectedEvent,
+		eventcheck.ErrSpilledEvent,
+		eventcheck.ErrDuplicateEvent,
+		basiccheck.ErrWrongNetForkID,
+		basiccheck.ErrZeroTime,
+		basiccheck.ErrNegativeValue,
+		basiccheck.ErrIntrinsicGas,
+		basiccheck.ErrTipAboveFeeCap,
+		basiccheck.ErrWrongMP,
+		basiccheck.ErrNoCrimeInMP,
+		basiccheck.ErrWrongCreatorMP,
+		basiccheck.ErrMPTooLate,
+		basiccheck.ErrMalformedMP,
+		basiccheck.FutureBVsEpoch,
+		basiccheck.FutureEVEpoch,
+		basiccheck.MalformedBVs,
+		basiccheck.MalformedEV,
+		basiccheck.TooManyBVs,
+		basiccheck.EmptyEV,
+		basiccheck.EmptyBVs,
+		epochcheck.ErrTooManyParents,
+		epochcheck.ErrTooBigGasUsed,
+		epochcheck.ErrWrongGasUsed,
+		epochcheck.ErrUnderpriced,
+		epochcheck.ErrTooBigExtra,
+		epochcheck.ErrWrongVersion,
+		epochcheck.ErrUnsupportedTxType,
+		epochcheck.ErrNotRelevant,
+		epochcheck.ErrAuth,
+		parentscheck.ErrPastTime,
+		gaspowercheck.ErrWrongGasPowerLeft,
+		heavycheck.ErrWrongEventSig,
+		heavycheck.ErrMalformedTxSig,
+		heavycheck.ErrWrongPayloadHash,
+		heavycheck.ErrPubkeyChanged,
+		heavycheck.ErrUnknownEpochEventLocator,
+		heavycheck.ErrImpossibleBVsEpoch,
+		heavycheck.ErrUnknownEpochBVs,
+		heavycheck.ErrUnknownEpochEV,
+		errStopped,
+		errWrongMedianTime,
+		errWrongEpochHash,
+	)
+	// otherRejectedMeter counts rejected events with the errors which aren't sentinel values,
+	// so the number of metrics stays bounded
+	otherRejectedMeter = metrics.GetOrRegisterMeter("gossip/rejected/other", nil)
+)
+
+func newRejectedCauseMeters(errs ...error) map[error]metrics.Meter {
+	meters := make(map[error]metrics.Meter, len(errs))
+	for _, err := range errs {
+		meters[err] = metrics.GetOrRegisterMeter("gossip/rejected/"+rejectionCauseName(err), nil)
+	}
+	return meters
+}
+
+// rejectionCauseName converts an error message into a metric name, e.g. "event has zero timestamp" -> "event_has_zero_timestamp"
+func rejectionCauseName(err error) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(err.Error()), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), "_")
+}
+
+// markRejectedEvent marks the meters of a rejected event
+func markRejectedEvent(err error) {
+	rejectedEventsMeter.Mark(1)
+	if meter, ok := rejectedCauseMeters[err]; ok {
+		meter.Mark(1)
+	} else {
+		otherRejectedMeter.Mark(1)
+	}
+}
@@ -0,0 +1,91 @@
+package gossip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/eventcheck"
+	"github.com/Fantom-foundation/go-opera/eventcheck/epochcheck"
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+func TestSplitEventsBySize(t *testing.T) {
+	require := require.New(t)
+
+	events := make(inter.EventPayloads, 5)
+	for i := range events {
+		me := &inter.MutableEventPayload{}
+		me.SetVersion(1)
+		me.SetExtra(make([]byte, 100))
+		events[i] = me.Build()
+	}
+	size := events[0].Size()
+
+	require.Empty(splitEventsBySize(nil, size))
+	require.Equal([]inter.EventPayloads{events}, splitEventsBySize(events, 5*size))
+	require.Equal([]inter.EventPayloads{events[:2], events[2:4], events[4:]}, splitEventsBySize(events, 2*size+1))
+	// events larger than the limit are sent one by one
+	require.Equal([]inter.EventPayloads{events[:1], events[1:2], events[2:3], events[3:4], events[4:]}, splitEventsBySize(events, size-1))
+}
+
+func TestRejectedCauseMeters(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("event_has_too_many_parents", rejectionCauseName(epochcheck.ErrTooManyParents))
+	require.Equal("max_priority_fee_per_gas_higher_than_max_fee_per_gas", rejectionCauseName(errors.New("max priority fee per gas higher than max fee per gas")))
+
+	// every sentinel error has its own meter
+	names := make(map[string]bool)
+	for err := range rejectedCauseMeters {
+		names[rejectionCauseName(err)] = true
+	}
+	require.Len(names, len(rejectedCauseMeters))
+	_, ok := rejectedCauseMeters[epochcheck.ErrTooManyParents]
+	require.True(ok)
+}
+
+func TestPeerPenalty(t *testing.T) {
+	require := require.New(t)
+
+	// events which are already known are expected from honest peers
+	require.Zero(peerPenaltyOf(nil))
+	require.Zero(peerPenaltyOf(eventcheck.ErrSpilledEvent))
+	require.Zero(peerPenaltyOf(eventcheck.ErrDuplicateEvent))
+	require.Zero(peerPenaltyOf(eventcheck.ErrAlreadyConnectedEvent))
+	require.Equal(uint32(duplicatePenalty), peerPenaltyOf(errRateLimited))
+	require.Equal(uint32(rejectedPenalty), peerPenaltyOf(epochcheck.ErrNotRelevant))
+
+	// only bans are persisted
+	store := NewMemStore()
+	defer store.Close()
+	id := enode.ID{1}
+	require.Zero(store.GetPeerBan(id))
+	store.SetPeerBan(id, 100)
+	require.Equal(inter.Timestamp(100), store.GetPeerBan(id))
+}
+
+func TestValidatorPeerSlots(t *testing.T) {
+	require := require.New(t)
+
+	require.True(isAdjacentEpoch(5, 5))
+	require.True(isAdjacentEpoch(4, 5))
+	require.True(isAdjacentEpoch(6, 5))
+	require.False(isAdjacentEpoch(3, 5))
+	require.False(isAdjacentEpoch(7, 5))
+
+	// the proof is bound to the challenger and the epoch
+	nonce := hash.Hash{1}
+	require.NotEqual(validatorProofDigest(nonce, enode.ID{1}, 5), validatorProofDigest(nonce, enode.ID{2}, 5))
+	require.NotEqual(validatorProofDigest(nonce, enode.ID{1}, 5), validatorProofDigest(nonce, enode.ID{1}, 6))
+
+	h := &handler{maxPeers: 50}
+	h.config.Protocol.ValidatorPeerSlots = 10
+	require.Equal(40, h.nonValidatorPeerSlots())
+	// at most a half of the slots is reserved
+	h.config.Protocol.ValidatorPeerSlots = 40
+	require.Equal(25, h.nonValidatorPeerSlots())
+}
@@ -0,0 +1,145 @@
+package gossip
+
+import (
+	"crypto/rand"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p"
+
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+)
+
+// validatorProofTimeout is the time for a peer to answer the validator proof request.
+// A peer which doesn't answer in time isn't considered a validator
+const validatorProofTimeout = 10 * time.Second
+
+var (
+	validatorPeersGauge = metrics.GetOrRegisterGauge("p2p/validator_peers", nil)
+)
+
+// challengeValidator requests the peer to prove that it's a validator of the current epoch.
+// Only FTM64 peers are challenged, older peers are never considered validators
+func (h *handler) challengeValidator(p *peer) error {
+	var nonce hash.Hash
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return err
+	}
+	challenged, err := p.RequestValidatorProof(nonce, h.store.GetEpoch())
+	if !challenged || err != nil {
+		return err
+	}
+	time.AfterFunc(validatorProofTimeout, func() {
+		if !p.ValidatorChallenged(nonce) {
+			return
+		}
+		p.SetValidator(0)
+		if h.tooManyNonValidatorPeers(p) {
+			p.Log().Debug("Dropping peer which hasn't proved to be a validator")
+			p.Disconnect(p2p.DiscTooManyPeers)
+		}
+	})
+	return nil
+}
+
+// proveValidator answers the validator proof request of the peer.
+// Zero validator ID is sent if there's no local validator of the current epoch,
+// or if the peer's epoch is too far from the local one to verify the proof
+func (h *handler) proveValidator(p *peer, challenge validatorChallenge) error {
+	if err := p.onValidatorProofRequest(challenge); err != nil {
+		return err
+	}
+	proof := validatorProof{
+		Epoch: h.store.GetEpoch(),
+	}
+	if h.signValidatorProof != nil && isAdjacentEpoch(challenge.Epoch, proof.Epoch) {
+		digest := validatorProofDigest(challenge.Nonce, p.ID(), proof.Epoch)
+		id, sig, err := h.signValidatorProof(digest.Bytes())
+		if err != nil {
+			h.Log.Warn("Failed to sign validator proof", "err", err)
+		} else if id != 0 {
+			proof.Validator = id
+			copy(proof.Sig[:], sig)
+		}
+	}
+	return p.SendValidatorProof(proof)
+}
+
+// verifyValidatorProof returns the validator which has signed the proof, or 0 if the proof is invalid.
+// The proof of a validator whose epoch differs from the local one by 1 is accepted, so the peers
+// aren't dropped when they switch epochs at slightly different times
+func (h *handler) verifyValidatorProof(nonce hash.Hash, proof validatorProof) idx.ValidatorID {
+	if proof.Validator == 0 || h.localID == nil {
+		return 0
+	}
+	epoch := h.store.GetEpoch()
+	if !isAdjacentEpoch(proof.Epoch, epoch) {
+		return 0
+	}
+	if proof.Epoch < epoch {
+		epoch = proof.Epoch
+	}
+	es := h.store.GetHistoryEpochState(epoch)
+	if es == nil || !es.Validators.Exists(proof.Validator) {
+		return 0
+	}
+	pubkey := es.ValidatorProfiles[proof.Validator].PubKey
+	if pubkey.Type != validatorpk.Types.Secp256k1 {
+		return 0
+	}
+	digest := validatorProofDigest(nonce, h.localID(), proof.Epoch)
+	if !crypto.VerifySignature(pubkey.Raw, digest.Bytes(), proof.Sig.Bytes()) {
+		return 0
+	}
+	return proof.Validator
+}
+
+// isAdjacentEpoch returns true if the epochs differ by at most 1
+func isAdjacentEpoch(a, b idx.Epoch) bool {
+	return a+1 >= b && a <= b+1
+}
+
+// onValidatorProof handles the answer to the validator proof request.
+// It returns false if the peer isn't a validator and occupies a slot reserved for validators
+func (h *handler) onValidatorProof(p *peer, proof validatorProof) (bool, error) {
+	nonce, ok := p.takeValidatorChallenge()
+	if !ok {
+		return false, errResp(ErrExtraStatusMsg, "unrequested validator proof")
+	}
+	p.SetValidator(h.verifyValidatorProof(nonce, proof))
+	validatorPeersGauge.Update(int64(h.peers.Len() - h.nonValidatorPeers()))
+	return !h.tooManyNonValidatorPeers(p), nil
+}
+
+// nonValidatorPeerSlots returns the number of peer slots which may be occupied by peers which aren't validators.
+// At most a half of the slots is reserved for validators
+func (h *handler) nonValidatorPeerSlots() int {
+	reserved := h.config.Protocol.ValidatorPeerSlots
+	if reserved > h.maxPeers/2 {
+		reserved = h.maxPeers / 2
+	}
+	return h.maxPeers - reserved
+}
+
+// nonValidatorPeers returns the number of registered peers which haven't proved to be validators
+func (h *handler) nonValidatorPeers() int {
+	n := 0
+	for _, p := range h.peers.List() {
+		if p.Validator() == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// tooManyNonValidatorPeers returns true if the registered peer isn't a validator, and peers
+// which aren't validators occupy the slots reserved for validators. Trusted peers are never dropped
+func (h *handler) tooManyNonValidatorPeers(p *peer) bool {
+	if p.Validator() != 0 || p.Peer.Info().Network.Trusted {
+		return false
+	}
+	return h.nonValidatorPeers() > h.nonValidatorPeerSlots()
+}
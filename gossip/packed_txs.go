@@ -0,0 +1,56 @@
+package gossip
+
+import (
+	"sync"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// maxTrackedPackedTxs limits the number of tracked transactions packed into events
+const maxTrackedPackedTxs = 100000
+
+// packedTxs tracks creators of events which have packed a transaction.
+// Only events connected since the node start are observed.
+type packedTxs struct {
+	creators *lru.Cache // tx hash -> []idx.ValidatorID
+
+	mu sync.Mutex
+}
+
+func newPackedTxs() *packedTxs {
+	creators, _ := lru.New(maxTrackedPackedTxs)
+	return &packedTxs{
+		creators: creators,
+	}
+}
+
+// Add is called when the event is connected to the DAG
+func (p *packedTxs) Add(e inter.EventPayloadI) {
+	if e.Txs().Len() == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, tx := range e.Txs() {
+		var creators []idx.ValidatorID
+		if v, ok := p.creators.Get(tx.Hash()); ok {
+			creators = v.([]idx.ValidatorID)
+		}
+		p.creators.Add(tx.Hash(), append(creators, e.Creator()))
+	}
+}
+
+// Creators returns creators of events which have packed the transaction
+func (p *packedTxs) Creators(txHash common.Hash) []idx.ValidatorID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v, ok := p.creators.Get(txHash)
+	if !ok {
+		return nil
+	}
+	return append([]idx.ValidatorID{}, v.([]idx.ValidatorID)...)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
@@ -22,6 +23,7 @@ import (
 	"github.com/Fantom-foundation/go-opera/gossip/protocols/dag/dagstream"
 	"github.com/Fantom-foundation/go-opera/gossip/protocols/epochpacks/epstream"
 	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/utils/rate"
 )
 
 var (
@@ -63,6 +65,19 @@ type peer struct {
 
 	progress PeerProgress
 
+	rateLimits peerRateLimits
+
+	throttledUntil int64 // UnixNano time until which the peer's broadcasts are ignored, atomic
+
+	// validator is the current epoch's validator which the peer has proved to be, 0 if it isn't a validator
+	validator idx.ValidatorID
+	// validatorChallenge is the nonce of the unanswered validator proof request, nil if there's none
+	validatorChallenge *hash.Hash
+	// challengedEpoch is the epoch of the last validator proof request sent to the peer
+	challengedEpoch idx.Epoch
+	// provedEpoch is the epoch of the last validator proof request received from the peer
+	provedEpoch idx.Epoch
+
 	snapExt  *snapPeer     // Satellite `snap` connection
 	syncDrop *time.Timer   // Connection dropper if `eth` sync progress isn't validated in time
 	snapWait chan struct{} // Notification channel for snap connections
@@ -131,6 +146,41 @@ func (p *peer) broadcast(queue chan broadcastItem) {
 	}
 }
 
+type peerRateLimits struct {
+	events *rate.Bucket
+	txs    *rate.Bucket
+	bytes  *rate.Bucket
+}
+
+func newRateLimitBucket(perSecond float64) *rate.Bucket {
+	if perSecond == 0 {
+		return nil
+	}
+	return rate.NewBucket(perSecond, perSecond)
+}
+
+func newPeerRateLimits(cfg PeerRateLimitConfig) peerRateLimits {
+	return peerRateLimits{
+		events: newRateLimitBucket(cfg.EventsPerSecond),
+		txs:    newRateLimitBucket(cfg.TxsPerSecond),
+		bytes:  newRateLimitBucket(cfg.BytesPerSecond),
+	}
+}
+
+func allowRate(limit *rate.Bucket, n int) bool {
+	return limit == nil || limit.Take(float64(n))
+}
+
+// ThrottleUntil ignores the peer's broadcasts until the given time.
+func (p *peer) ThrottleUntil(t time.Time) {
+	atomic.StoreInt64(&p.throttledUntil, t.UnixNano())
+}
+
+// Throttled returns true if the peer's broadcasts are ignored.
+func (p *peer) Throttled() bool {
+	return time.Now().UnixNano() < atomic.LoadInt64(&p.throttledUntil)
+}
+
 // Close signals the broadcast goroutine to terminate.
 func (p *peer) Close() {
 	p.queuedDataSemaphore.Terminate()
@@ -417,6 +467,83 @@ func (p *peer) RequestEvents(ids hash.Events) error {
 	return nil
 }
 
+// RequestCreatorEventIDs requests IDs of events by a creator within [from, to] sequence numbers range.
+func (p *peer) RequestCreatorEventIDs(epoch idx.Epoch, creator idx.ValidatorID, from, to idx.Event) error {
+	p.Log().Debug("Fetching creator's event IDs", "epoch", epoch, "creator", creator, "from", from, "to", to)
+	return p2p.Send(p.rw, GetCreatorEventIDsMsg, &creatorEventIDsRequest{
+		Epoch:   epoch,
+		Creator: creator,
+		From:    from,
+		To:      to,
+	})
+}
+
+// RequestValidatorProof challenges the peer to prove that it's a validator of the current epoch.
+// The peer is challenged at most once per epoch, false is returned if it's already challenged in the epoch.
+func (p *peer) RequestValidatorProof(nonce hash.Hash, epoch idx.Epoch) (bool, error) {
+	p.Lock()
+	if epoch <= p.challengedEpoch {
+		p.Unlock()
+		return false, nil
+	}
+	p.challengedEpoch = epoch
+	p.validatorChallenge = &nonce
+	p.Unlock()
+	return true, p2p.Send(p.rw, GetValidatorProofMsg, &validatorChallenge{
+		Nonce: nonce,
+		Epoch: epoch,
+	})
+}
+
+// onValidatorProofRequest checks that the peer requests a validator proof at most once per its epoch.
+func (p *peer) onValidatorProofRequest(challenge validatorChallenge) error {
+	p.Lock()
+	defer p.Unlock()
+	if challenge.Epoch <= p.provedEpoch {
+		return errResp(ErrExtraStatusMsg, "repeated validator proof request")
+	}
+	p.provedEpoch = challenge.Epoch
+	return nil
+}
+
+// SendValidatorProof answers the peer's validator proof request.
+func (p *peer) SendValidatorProof(proof validatorProof) error {
+	return p2p.Send(p.rw, ValidatorProofMsg, &proof)
+}
+
+// takeValidatorChallenge returns the nonce of the unanswered validator proof request and marks it answered.
+func (p *peer) takeValidatorChallenge() (hash.Hash, bool) {
+	p.Lock()
+	defer p.Unlock()
+	if p.validatorChallenge == nil {
+		return hash.Hash{}, false
+	}
+	nonce := *p.validatorChallenge
+	p.validatorChallenge = nil
+	return nonce, true
+}
+
+// ValidatorChallenged returns true if the peer hasn't answered the validator proof request with the nonce yet.
+func (p *peer) ValidatorChallenged(nonce hash.Hash) bool {
+	p.RLock()
+	defer p.RUnlock()
+	return p.validatorChallenge != nil && *p.validatorChallenge == nonce
+}
+
+// SetValidator sets the validator which the peer has proved to be, 0 if it isn't a validator.
+func (p *peer) SetValidator(id idx.ValidatorID) {
+	p.Lock()
+	defer p.Unlock()
+	p.validator = id
+}
+
+// Validator returns the current epoch's validator which the peer has proved to be, 0 if it isn't a validator.
+func (p *peer) Validator() idx.ValidatorID {
+	p.RLock()
+	defer p.RUnlock()
+	return p.validator
+}
+
 func (p *peer) RequestTransactions(txids []common.Hash) error {
 	// divide big batch into smaller ones
 	for start := 0; start < len(txids); start += softLimitItems {
@@ -574,5 +701,5 @@ func (p *snapPeer) info() *snapPeerInfo {
 
 // eligibleForSnap checks eligibility of a peer for a snap protocol. A peer is eligible for a snap if it advertises `snap` sattelite protocol along with `opera` protocol.
 func eligibleForSnap(p *p2p.Peer) bool {
-	return p.RunningCap(ProtocolName, []uint{FTM63}) && p.RunningCap(snap.ProtocolName, snap.ProtocolVersions)
+	return p.RunningCap(ProtocolName, []uint{FTM63, FTM64}) && p.RunningCap(snap.ProtocolName, snap.ProtocolVersions)
 }
@@ -0,0 +1,107 @@
+package gossip
+
+import (
+	"sync"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// maxPendingEventTxs limits the number of tracked transactions which are packed into events, but not finalized yet
+const maxPendingEventTxs = 5000
+
+// pendingEventTxs tracks transactions which are packed into connected events of the current epoch, but not finalized yet.
+// It's updated incrementally on connected events and processed blocks.
+type pendingEventTxs struct {
+	epoch idx.Epoch
+	// txs are ordered by the connection of carrying events, some of them may be already finalized
+	txs     []*types.Transaction
+	pending map[common.Hash]bool
+	// finalized is recently finalized txs, which prevents re-adding of a tx carried by several events
+	finalized *lru.Cache
+	// version is changed on every change of the pending txs
+	version uint64
+
+	mu sync.Mutex
+}
+
+func newPendingEventTxs() *pendingEventTxs {
+	finalized, _ := lru.New(maxPendingEventTxs)
+	return &pendingEventTxs{
+		pending:   make(map[common.Hash]bool),
+		finalized: finalized,
+	}
+}
+
+// Add is called when the event is connected to the DAG
+func (p *pendingEventTxs) Add(e inter.EventPayloadI) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e.Epoch() != p.epoch {
+		// txs of not confirmed events of the previous epoch are dropped
+		p.epoch = e.Epoch()
+		p.txs = nil
+		p.pending = make(map[common.Hash]bool)
+		p.version++
+	}
+	for _, tx := range e.Txs() {
+		if len(p.pending) >= maxPendingEventTxs {
+			return
+		}
+		if p.pending[tx.Hash()] || p.finalized.Contains(tx.Hash()) {
+			continue
+		}
+		p.pending[tx.Hash()] = true
+		p.txs = append(p.txs, tx)
+		p.version++
+	}
+}
+
+// Finalize is called when events are confirmed, their txs are either executed or skipped
+func (p *pendingEventTxs) Finalize(events inter.EventPayloads) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range events {
+		for _, tx := range e.Txs() {
+			p.finalized.Add(tx.Hash(), true)
+			if p.pending[tx.Hash()] {
+				delete(p.pending, tx.Hash())
+				p.version++
+			}
+		}
+	}
+	// compact the finalized txs
+	if len(p.txs) > 2*len(p.pending) {
+		txs := make([]*types.Transaction, 0, len(p.pending))
+		for _, tx := range p.txs {
+			if p.pending[tx.Hash()] {
+				txs = append(txs, tx)
+			}
+		}
+		p.txs = txs
+	}
+}
+
+// Version returns an identifier of the current pending txs
+func (p *pendingEventTxs) Version() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.version
+}
+
+// Txs returns the pending txs in order of the connection of carrying events
+func (p *pendingEventTxs) Txs() types.Transactions {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	txs := make(types.Transactions, 0, len(p.pending))
+	for _, tx := range p.txs {
+		if p.pending[tx.Hash()] {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
@@ -0,0 +1,47 @@
+package gossip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+func TestPendingEventTxs(t *testing.T) {
+	require := require.New(t)
+
+	tx := func(nonce uint64) *types.Transaction {
+		return types.NewTransaction(nonce, [20]byte{}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	}
+	event := func(epoch idx.Epoch, txs ...*types.Transaction) *inter.EventPayload {
+		me := &inter.MutableEventPayload{}
+		me.SetEpoch(epoch)
+		me.SetTxs(txs)
+		return me.Build()
+	}
+	tx1, tx2, tx3 := tx(1), tx(2), tx(3)
+
+	p := newPendingEventTxs()
+	p.Add(event(1, tx1, tx2))
+	v := p.Version()
+	require.Equal(types.Transactions{tx1, tx2}, p.Txs())
+
+	// events without new txs don't change the pending txs
+	p.Add(event(1))
+	p.Add(event(1, tx2))
+	require.Equal(v, p.Version())
+
+	// finalized txs aren't re-added
+	p.Finalize(inter.EventPayloads{event(1, tx1)})
+	require.NotEqual(v, p.Version())
+	p.Add(event(1, tx1, tx3))
+	require.Equal(types.Transactions{tx2, tx3}, p.Txs())
+
+	// txs of the previous epoch are dropped
+	p.Add(event(2))
+	require.Empty(p.Txs())
+}
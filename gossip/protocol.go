@@ -5,7 +5,10 @@ import (
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	notify "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 
+	"github.com/Fantom-foundation/go-opera/evmcore"
 	"github.com/Fantom-foundation/go-opera/gossip/emitter"
 	"github.com/Fantom-foundation/go-opera/inter"
 	"github.com/Fantom-foundation/go-opera/inter/ibr"
@@ -16,17 +19,18 @@ import (
 const (
 	FTM62           = 62
 	FTM63           = 63
-	ProtocolVersion = FTM63
+	FTM64           = 64
+	ProtocolVersion = FTM64
 )
 
 // ProtocolName is the official short name of the protocol used during capability negotiation.
 const ProtocolName = "opera"
 
 // ProtocolVersions are the supported versions of the protocol (first is primary).
-var ProtocolVersions = []uint{FTM62, FTM63}
+var ProtocolVersions = []uint{FTM62, FTM63, FTM64}
 
 // protocolLengths are the number of implemented message corresponding to different protocol versions.
-var protocolLengths = map[uint]uint64{FTM62: EventsStreamResponse + 1, FTM63: EPsStreamResponse + 1}
+var protocolLengths = map[uint]uint64{FTM62: EventsStreamResponse + 1, FTM63: EPsStreamResponse + 1, FTM64: ValidatorProofMsg + 1}
 
 const protocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
@@ -65,6 +69,16 @@ const (
 	BRsStreamResponse = 13
 	RequestEPsStream  = 14
 	EPsStreamResponse = 15
+
+	// Request IDs of events by a creator within a range of sequence numbers.
+	// Answered with NewEventIDsMsg
+	GetCreatorEventIDsMsg = 16
+
+	// Request a proof that the peer is a validator of the current epoch, sent at most once per epoch.
+	// Answered with ValidatorProofMsg
+	GetValidatorProofMsg = 17
+	// Contains a validator's signature of the challenge, or zero validator ID if the peer isn't a validator
+	ValidatorProofMsg = 18
 )
 
 type errCode int
@@ -117,6 +131,8 @@ type TxPool interface {
 	Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
 	ContentFrom(addr common.Address) (types.Transactions, types.Transactions)
 	PendingSlice() types.Transactions
+
+	SubscribeDroppedTxsNotify(chan<- evmcore.DroppedTxsNotify) notify.Subscription
 }
 
 // handshakeData is the network packet for the initial handshake message
@@ -126,6 +142,34 @@ type handshakeData struct {
 	Genesis         common.Hash
 }
 
+// creatorEventIDsRequest is the network packet for GetCreatorEventIDsMsg
+type creatorEventIDsRequest struct {
+	Epoch   idx.Epoch
+	Creator idx.ValidatorID
+	From    idx.Event
+	To      idx.Event
+}
+
+// validatorChallenge is the network packet for GetValidatorProofMsg
+type validatorChallenge struct {
+	Nonce hash.Hash
+	// Epoch is the challenger's epoch, a peer is challenged at most once per epoch
+	Epoch idx.Epoch
+}
+
+// validatorProof is the network packet for ValidatorProofMsg
+type validatorProof struct {
+	Epoch     idx.Epoch
+	Validator idx.ValidatorID
+	Sig       inter.Signature
+}
+
+// validatorProofDigest is signed by a validator to prove its identity to the challenger.
+// It's bound to the challenger's node ID, so a proof can't be relayed to another node
+func validatorProofDigest(nonce hash.Hash, challenger enode.ID, epoch idx.Epoch) hash.Hash {
+	return hash.Of([]byte("opera validator proof"), nonce.Bytes(), challenger.Bytes(), epoch.Bytes())
+}
+
 // PeerProgress is synchronization status of a peer
 type PeerProgress struct {
 	Epoch            idx.Epoch
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/gossip/basestream/basestreamleecher/basepeerleecher"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 )
 
 type Config struct {
@@ -13,6 +14,8 @@ type Config struct {
 	BaseSessionWatchdog  time.Duration
 	MinSessionRestart    time.Duration
 	MaxSessionRestart    time.Duration
+	// MaxEpochsAhead is the maximum number of sealed epochs to download in bulk ahead of the lowest undecided epoch
+	MaxEpochsAhead idx.Epoch
 }
 
 // DefaultConfig returns default leecher config
@@ -29,6 +32,7 @@ func DefaultConfig() Config {
 		BaseSessionWatchdog:  time.Second * 30 * 5,
 		MinSessionRestart:    time.Second * 5,
 		MaxSessionRestart:    time.Minute * 5,
+		MaxEpochsAhead:       10000,
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	notify "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
@@ -55,13 +56,22 @@ import (
 	"github.com/Fantom-foundation/go-opera/vecmt"
 )
 
+// newEventQueueSize is a max number of event notifications waiting for the subscribers
+const newEventQueueSize = 4096
+
+var droppedEventNotifiesMeter = metrics.GetOrRegisterMeter("gossip/feed/dropped_event_notifies", nil)
+
 type ServiceFeed struct {
 	scope notify.SubscriptionScope
 
 	newEpoch        notify.Feed
 	newEmittedEvent notify.Feed
+	newEvent        notify.Feed
 	newBlock        notify.Feed
 	newLogs         notify.Feed
+
+	// newEventQueue decouples the events processing from the newEvent subscribers
+	newEventQueue chan ethapi.EventNotify
 }
 
 func (f *ServiceFeed) SubscribeNewEpoch(ch chan<- idx.Epoch) notify.Subscription {
@@ -72,6 +82,32 @@ func (f *ServiceFeed) SubscribeNewEmitted(ch chan<- *inter.EventPayload) notify.
 	return f.scope.Track(f.newEmittedEvent.Subscribe(ch))
 }
 
+func (f *ServiceFeed) SubscribeNewEvent(ch chan<- ethapi.EventNotify) notify.Subscription {
+	return f.scope.Track(f.newEvent.Subscribe(ch))
+}
+
+// sendNewEvent queues the notification for newEventLoop. It's called under engineMu,
+// so it never waits for the subscribers and drops the notification if the queue is full
+func (f *ServiceFeed) sendNewEvent(ev ethapi.EventNotify) {
+	select {
+	case f.newEventQueue <- ev:
+	default:
+		droppedEventNotifiesMeter.Mark(1)
+	}
+}
+
+// newEventLoop sends the queued notifications to the newEvent subscribers until quit is closed
+func (f *ServiceFeed) newEventLoop(quit <-chan struct{}) {
+	for {
+		select {
+		case ev := <-f.newEventQueue:
+			f.newEvent.Send(ev)
+		case <-quit:
+			return
+		}
+	}
+}
+
 func (f *ServiceFeed) SubscribeNewBlock(ch chan<- evmcore.ChainHeadNotify) notify.Subscription {
 	return f.scope.Track(f.newBlock.Subscribe(ch))
 }
@@ -121,6 +157,9 @@ type Service struct {
 	gasPowerCheckReader GasPowerCheckReader
 	checkers            *eventcheck.Checkers
 	uniqueEventIDs      uniqueID
+	eventsLatency       *eventsLatency
+	packedTxs           *packedTxs
+	pendingEventTxs     *pendingEventTxs
 
 	// version watcher
 	verWatcher *verwatcher.VerWarcher
@@ -134,6 +173,8 @@ type Service struct {
 	eventBusyFlag uint32
 
 	feed     ServiceFeed
+	feedWg   sync.WaitGroup
+	feedQuit chan struct{}
 	eventMux *event.TypeMux
 
 	gpo *gasprice.Oracle
@@ -153,6 +194,7 @@ type Service struct {
 	haltCheck func(oldEpoch, newEpoch idx.Epoch, time time.Time) bool
 
 	tflusher PeriodicFlusher
+	tpruner  PeriodicPruner
 
 	logger.Instance
 }
@@ -183,6 +225,7 @@ func newService(config Config, store *Store, blockProc BlockProc, engine lachesi
 	svc := &Service{
 		config:             config,
 		blockProcTasksDone: make(chan struct{}),
+		feedQuit:           make(chan struct{}),
 		Name:               fmt.Sprintf("Node-%d", rand.Int()),
 		store:              store,
 		engine:             engine,
@@ -190,11 +233,15 @@ func newService(config Config, store *Store, blockProc BlockProc, engine lachesi
 		dagIndexer:         dagIndexer,
 		engineMu:           new(sync.RWMutex),
 		uniqueEventIDs:     uniqueID{new(big.Int)},
+		eventsLatency:      newEventsLatency(),
+		packedTxs:          newPackedTxs(),
+		pendingEventTxs:    newPendingEventTxs(),
 		procLogger:         proclogger.NewLogger(),
 		Instance:           logger.New("gossip-service"),
 	}
 
 	svc.blockProcTasks = workers.New(new(sync.WaitGroup), svc.blockProcTasksDone, 1)
+	svc.feed.newEventQueue = make(chan ethapi.EventNotify, newEventQueueSize)
 
 	// load epoch DB
 	svc.store.loadEpochStore(svc.store.GetEpoch())
@@ -257,16 +304,24 @@ func newService(config Config, store *Store, blockProc BlockProc, engine lachesi
 			EV:               svc.ProcessEpochVote,
 			ER:               svc.ProcessFullEpochRecord,
 		},
+		localID: func() enode.ID {
+			if svc.p2pServer == nil || svc.p2pServer.LocalNode() == nil {
+				return enode.ID{}
+			}
+			return svc.p2pServer.LocalNode().ID()
+		},
+		signValidatorProof: svc.signValidatorProof,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// create API backend
-	svc.EthAPI = &EthAPIBackend{config.ExtRPCEnabled, svc, stateReader, txSigner, config.AllowUnprotectedTxs}
+	svc.EthAPI = &EthAPIBackend{config.ExtRPCEnabled, svc, stateReader, txSigner, config.AllowUnprotectedTxs, &pendingState{}}
 
 	svc.verWatcher = verwatcher.New(verwatcher.NewStore(store.table.NetworkVersion))
 	svc.tflusher = svc.makePeriodicFlusher()
+	svc.tpruner = svc.makePeriodicPruner()
 
 	return svc, nil
 }
@@ -319,6 +374,33 @@ func (s *Service) makePeriodicFlusher() PeriodicFlusher {
 	}
 }
 
+// makePeriodicPruner makes PeriodicPruner
+func (s *Service) makePeriodicPruner() PeriodicPruner {
+	period := s.store.cfg.Retention.Period
+	if !s.store.isPruningEnabled() {
+		period = 0
+	}
+	return PeriodicPruner{
+		period: period,
+		prune: func() {
+			// only the bounds are taken under the lock, the deletion doesn't block events processing
+			s.engineMu.Lock()
+			s.blockProcWg.Wait()
+			stopped := s.stopped
+			bounds := s.store.historyToPrune()
+			s.engineMu.Unlock()
+			if stopped {
+				return
+			}
+			if err := s.store.pruneHistory(bounds); err != nil {
+				s.Log.Error("Failed to prune historical data", "err", err)
+			}
+		},
+		wg:   sync.WaitGroup{},
+		quit: make(chan struct{}),
+	}
+}
+
 func (s *Service) EmitterWorld(signer valkeystore.SignerI) emitter.World {
 	return emitter.World{
 		External: &emitterWorld{
@@ -332,6 +414,17 @@ func (s *Service) EmitterWorld(signer valkeystore.SignerI) emitter.World {
 	}
 }
 
+// signValidatorProof signs a validator proof by a local validator of the current epoch, if any
+func (s *Service) signValidatorProof(digest []byte) (idx.ValidatorID, []byte, error) {
+	for _, em := range s.emitters {
+		id, sig, err := em.SignValidatorProof(digest)
+		if err != nil || id != 0 {
+			return id, sig, err
+		}
+	}
+	return 0, nil, nil
+}
+
 // RegisterEmitter must be called before service is started
 func (s *Service) RegisterEmitter(em *emitter.Emitter) {
 	s.emitters = append(s.emitters, em)
@@ -350,7 +443,7 @@ func MakeProtocols(svc *Service, backend *handler, disc enode.Iterator) []p2p.Pr
 			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
 				// wait until handler has started
 				backend.started.Wait()
-				peer := newPeer(version, p, rw, backend.config.Protocol.PeerCache)
+				peer := newPeer(version, p, wrapChaosMsgReadWriter(svc, p, rw), backend.config.Protocol.PeerCache)
 				defer peer.Close()
 
 				select {
@@ -411,6 +504,10 @@ func (s *Service) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   s.netRPCService,
 			Public:    true,
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   NewPrivateAdminAPI(s),
 		},
 	}...)
 
@@ -422,6 +519,7 @@ func (s *Service) Start() error {
 	s.gpo.Start(&GPOBackend{s.store, s.txpool})
 	// start tflusher before starting snapshots generation
 	s.tflusher.Start()
+	s.tpruner.Start()
 	// start snapshots generation
 	if s.store.evm.IsEvmSnapshotPaused() && !s.config.AllowSnapsync {
 		return errors.New("cannot halt snapsync and start fullsync")
@@ -438,6 +536,13 @@ func (s *Service) Start() error {
 	// start blocks processor
 	s.blockProcTasks.Start(1)
 
+	// start events notifications
+	s.feedWg.Add(1)
+	go func() {
+		defer s.feedWg.Done()
+		s.feed.newEventLoop(s.feedQuit)
+	}()
+
 	// start p2p
 	StartENRUpdater(s, s.p2pServer.LocalNode())
 	s.handler.Start(s.p2pServer.MaxPeers)
@@ -476,10 +581,13 @@ func (s *Service) Stop() error {
 
 	s.handler.Stop()
 	s.feed.scope.Close()
+	close(s.feedQuit)
+	s.feedWg.Wait()
 	s.eventMux.Stop()
 	s.gpo.Stop()
-	// it's safe to stop tflusher only before locking engineMu
+	// it's safe to stop tflusher and tpruner only before locking engineMu
 	s.tflusher.Stop()
+	s.tpruner.Stop()
 
 	// flush the state at exit, after all the routines stopped
 	s.engineMu.Lock()
@@ -0,0 +1,27 @@
+package simnet
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock is a node's view of the simulated time, which is shifted from the network time by the node's clock skew.
+// It's safe for concurrent use.
+type Clock struct {
+	skew time.Duration
+	now  int64
+}
+
+// Now returns the node's current time.
+func (c *Clock) Now() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.now))
+}
+
+// Skew returns the offset of the node's clock from the network time.
+func (c *Clock) Skew() time.Duration {
+	return c.skew
+}
+
+func (c *Clock) set(networkTime time.Time) {
+	atomic.StoreInt64(&c.now, networkTime.Add(c.skew).UnixNano())
+}
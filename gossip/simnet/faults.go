@@ -0,0 +1,57 @@
+package simnet
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Rand is a source of randomness of faults.
+// *math/rand.Rand satisfies it.
+type Rand interface {
+	Float64() float64
+	Int63n(n int64) int64
+}
+
+// Faults describes faults of messages delivery between nodes.
+// It's shared by the simulated network and the fault injection into real p2p connections.
+type Faults struct {
+	// DropRate is a probability of a message to be dropped
+	DropRate float64
+	// MinDelay and MaxDelay limit a uniformly distributed delay of messages
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Partitioned returns true if the local node must ignore all the messages of the remote one
+	Partitioned func(local, remote enode.ID) bool
+}
+
+// Drop returns true if a message which is sent by the remote node to the local node must be dropped.
+func (f Faults) Drop(r Rand, local, remote enode.ID) bool {
+	if f.Partitioned != nil && f.Partitioned(local, remote) {
+		return true
+	}
+	return f.DropRate > 0 && r.Float64() < f.DropRate
+}
+
+// Delay returns a delay of a message.
+func (f Faults) Delay(r Rand) time.Duration {
+	spread := int64(f.MaxDelay - f.MinDelay)
+	if spread <= 0 {
+		return f.MinDelay
+	}
+	return f.MinDelay + time.Duration(r.Int63n(spread+1))
+}
+
+// Groups returns a partition function which splits nodes into the groups.
+// Nodes which aren't mentioned form one more group.
+func Groups(groups ...[]enode.ID) func(local, remote enode.ID) bool {
+	groupOf := make(map[enode.ID]int)
+	for i, group := range groups {
+		for _, id := range group {
+			groupOf[id] = i + 1
+		}
+	}
+	return func(local, remote enode.ID) bool {
+		return groupOf[local] != groupOf[remote]
+	}
+}
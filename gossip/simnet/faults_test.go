@@ -0,0 +1,45 @@
+package simnet
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(0))
+	a, b, c := enode.ID{1}, enode.ID{2}, enode.ID{3}
+
+	// no faults
+	f := Faults{}
+	require.False(f.Drop(r, a, b))
+	require.Equal(time.Duration(0), f.Delay(r))
+
+	// delays are within the limits
+	f = Faults{
+		MinDelay: time.Second,
+		MaxDelay: 2 * time.Second,
+	}
+	for i := 0; i < 100; i++ {
+		d := f.Delay(r)
+		require.True(d >= f.MinDelay && d <= f.MaxDelay, d)
+	}
+
+	// all the messages are dropped
+	f = Faults{DropRate: 1}
+	require.True(f.Drop(r, a, b))
+
+	// partitions
+	f = Faults{Partitioned: Groups([]enode.ID{a, b})}
+	require.False(f.Drop(r, a, b))
+	require.True(f.Drop(r, a, c))
+	require.True(f.Drop(r, c, b))
+	f = Faults{Partitioned: Groups([]enode.ID{a}, []enode.ID{b})}
+	require.True(f.Drop(r, a, b))
+	require.True(f.Drop(r, b, c))
+	require.False(f.Drop(r, c, c))
+}
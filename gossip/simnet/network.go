@@ -0,0 +1,187 @@
+package simnet
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// Node is a simulated node.
+type Node interface {
+	// Step processes the events received since the previous step and returns the newly emitted events.
+	// It's called once per network step, after the node's clock is advanced.
+	Step(received []*inter.EventPayload) (emitted []*inter.EventPayload, err error)
+}
+
+// Config is a configuration of the simulated network.
+type Config struct {
+	// Seed determines the network schedule: clock skews, delays and drops of messages
+	Seed   int64
+	Faults Faults
+	// MaxClockSkew is a max absolute offset of a node's clock from the network time
+	MaxClockSkew time.Duration
+	// RetryInterval is a period after which a dropped message is sent again, it models the events sync.
+	// Zero value means that the dropped messages are lost
+	RetryInterval time.Duration
+}
+
+type message struct {
+	deliverAt time.Time
+	seq       uint64
+	from, to  int
+	e         *inter.EventPayload
+}
+
+type member struct {
+	id       enode.ID
+	node     Node
+	clock    *Clock
+	received []*inter.EventPayload
+}
+
+// Network runs nodes in a lockstep over a simulated network.
+// The network schedule is derived only from the seed, so a run is reproducible
+// as long as the nodes use only their simulated clocks.
+type Network struct {
+	cfg     Config
+	rng     *rand.Rand
+	now     time.Time
+	members []*member
+	queue   []*message
+	seq     uint64
+}
+
+// New creates an empty network, which starts at the specified time.
+func New(start time.Time, cfg Config) *Network {
+	return &Network{
+		cfg: cfg,
+		rng: rand.New(rand.NewSource(cfg.Seed)),
+		now: start,
+	}
+}
+
+// Now returns the network time.
+func (n *Network) Now() time.Time {
+	return n.now
+}
+
+// NewClock creates a node's clock with a random skew within MaxClockSkew.
+func (n *Network) NewClock() *Clock {
+	c := &Clock{}
+	if n.cfg.MaxClockSkew > 0 {
+		c.skew = time.Duration(n.rng.Int63n(2*int64(n.cfg.MaxClockSkew)+1)) - n.cfg.MaxClockSkew
+	}
+	c.set(n.now)
+	return c
+}
+
+// Add joins the node to the network. The node's clock is advanced by the network.
+func (n *Network) Add(id enode.ID, node Node, clock *Clock) {
+	clock.set(n.now)
+	n.members = append(n.members, &member{
+		id:    id,
+		node:  node,
+		clock: clock,
+	})
+}
+
+// SetFaults changes the faults of messages which are delivered since now.
+func (n *Network) SetFaults(faults Faults) {
+	n.cfg.Faults = faults
+}
+
+// Partition splits nodes into the groups, messages between different groups are dropped until Heal.
+// Nodes which aren't mentioned form one more group.
+func (n *Network) Partition(groups ...[]enode.ID) {
+	n.cfg.Faults.Partitioned = Groups(groups...)
+}
+
+// Heal removes partitions.
+func (n *Network) Heal() {
+	n.cfg.Faults.Partitioned = nil
+}
+
+func (n *Network) send(from, to int, e *inter.EventPayload, at time.Time) {
+	n.seq++
+	n.queue = append(n.queue, &message{
+		deliverAt: at.Add(n.cfg.Faults.Delay(n.rng)),
+		seq:       n.seq,
+		from:      from,
+		to:        to,
+		e:         e,
+	})
+}
+
+func (n *Network) broadcast(from int, e *inter.EventPayload) {
+	for to := range n.members {
+		if to != from {
+			n.send(from, to, e, n.now)
+		}
+	}
+}
+
+// deliver passes the due messages to the receivers, unless the messages are dropped.
+func (n *Network) deliver() {
+	sort.Slice(n.queue, func(i, j int) bool {
+		if !n.queue[i].deliverAt.Equal(n.queue[j].deliverAt) {
+			return n.queue[i].deliverAt.Before(n.queue[j].deliverAt)
+		}
+		return n.queue[i].seq < n.queue[j].seq
+	})
+	due := 0
+	for _, msg := range n.queue {
+		if msg.deliverAt.After(n.now) {
+			break
+		}
+		due++
+	}
+	messages := n.queue[:due]
+	n.queue = n.queue[due:]
+	for _, msg := range messages {
+		to := n.members[msg.to]
+		if !n.cfg.Faults.Drop(n.rng, to.id, n.members[msg.from].id) {
+			to.received = append(to.received, msg.e)
+		} else if n.cfg.RetryInterval > 0 {
+			n.send(msg.from, msg.to, msg.e, msg.deliverAt.Add(n.cfg.RetryInterval))
+		}
+	}
+}
+
+// Step advances the network time, delivers the due messages and lets every node to make a step.
+func (n *Network) Step(step time.Duration) error {
+	n.now = n.now.Add(step)
+	for _, m := range n.members {
+		m.clock.set(n.now)
+	}
+	n.deliver()
+	for i, m := range n.members {
+		received := m.received
+		m.received = nil
+		emitted, err := m.node.Step(received)
+		if err != nil {
+			return fmt.Errorf("node %s: %v", m.id.TerminalString(), err)
+		}
+		for _, e := range emitted {
+			n.broadcast(i, e)
+		}
+	}
+	return nil
+}
+
+// RunUntil makes steps until stop returns true.
+func (n *Network) RunUntil(step time.Duration, stop func() bool, maxSteps int) error {
+	for i := 0; !stop(); i++ {
+		if i >= maxSteps {
+			return fmt.Errorf("no progress after %d steps", maxSteps)
+		}
+		if err := n.Step(step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
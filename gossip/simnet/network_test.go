@@ -0,0 +1,206 @@
+package simnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/Fantom-foundation/lachesis-base/lachesis"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/integration/fakeengine"
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// testNode emits an event on every step and decides the events by the fake engine
+type testNode struct {
+	id         idx.ValidatorID
+	validators *pos.Validators
+	clock      *Clock
+	engine     *fakeengine.Engine
+	known      map[hash.Event]bool
+	last       map[idx.ValidatorID]hash.Event
+	seq        idx.Event
+	pending    []*inter.EventPayload
+	blocks     hash.Events
+	stopped    bool
+}
+
+func newTestNode(t *testing.T, id idx.ValidatorID, validators *pos.Validators, clock *Clock) *testNode {
+	node := &testNode{
+		id:         id,
+		validators: validators,
+		clock:      clock,
+		engine:     fakeengine.New(1, validators),
+		known:      make(map[hash.Event]bool),
+		last:       make(map[idx.ValidatorID]hash.Event),
+	}
+	require.NoError(t, node.engine.Bootstrap(lachesis.ConsensusCallbacks{
+		BeginBlock: func(block *lachesis.Block) lachesis.BlockCallbacks {
+			node.blocks = append(node.blocks, block.Atropos)
+			return lachesis.BlockCallbacks{}
+		},
+	}))
+	return node
+}
+
+func (node *testNode) process(e *inter.EventPayload) error {
+	if err := node.engine.Process(e); err != nil {
+		return err
+	}
+	node.known[e.ID()] = true
+	node.last[e.Creator()] = e.ID()
+	return nil
+}
+
+func (node *testNode) Step(received []*inter.EventPayload) ([]*inter.EventPayload, error) {
+	node.pending = append(node.pending, received...)
+	for progress := true; progress; {
+		progress = false
+		rest := node.pending[:0]
+		for _, e := range node.pending {
+			if node.known[e.ID()] {
+				continue
+			}
+			ready := true
+			for _, p := range e.Parents() {
+				ready = ready && node.known[p]
+			}
+			if !ready {
+				rest = append(rest, e)
+				continue
+			}
+			if err := node.process(e); err != nil {
+				return nil, err
+			}
+			progress = true
+		}
+		node.pending = rest
+	}
+	if node.stopped {
+		return nil, nil
+	}
+
+	node.seq++
+	parents := hash.Events{}
+	if self, ok := node.last[node.id]; ok {
+		parents = append(parents, self)
+	}
+	for _, creator := range node.validators.IDs() {
+		if last, ok := node.last[creator]; ok && creator != node.id {
+			parents = append(parents, last)
+		}
+	}
+	me := &inter.MutableEventPayload{}
+	me.SetEpoch(1)
+	me.SetCreator(node.id)
+	me.SetSeq(node.seq)
+	me.SetLamport(idx.Lamport(len(node.known) + 1))
+	me.SetCreationTime(inter.Timestamp(node.clock.Now().UnixNano()))
+	me.SetParents(parents)
+	if err := node.engine.Build(me); err != nil {
+		return nil, err
+	}
+	e := me.Build()
+	if err := node.process(e); err != nil {
+		return nil, err
+	}
+	return []*inter.EventPayload{e}, nil
+}
+
+func testNodeID(id idx.ValidatorID) enode.ID {
+	return enode.ID{byte(id)}
+}
+
+// runTestNetwork runs nodes, partitioned for a while, and waits until every node knows all the events
+func runTestNetwork(t *testing.T, seed int64) []*testNode {
+	require := require.New(t)
+
+	ids := []idx.ValidatorID{1, 2, 3, 4}
+	validators := pos.ArrayToValidators(ids, []pos.Weight{1, 1, 1, 1})
+	net := New(time.Unix(1608600000, 0), Config{
+		Seed: seed,
+		Faults: Faults{
+			DropRate: 0.1,
+			MinDelay: 100 * time.Millisecond,
+			MaxDelay: 3 * time.Second,
+		},
+		MaxClockSkew:  500 * time.Millisecond,
+		RetryInterval: time.Second,
+	})
+	var nodes []*testNode
+	for _, id := range ids {
+		clock := net.NewClock()
+		node := newTestNode(t, id, validators, clock)
+		nodes = append(nodes, node)
+		net.Add(testNodeID(id), node, clock)
+	}
+
+	for i := 0; i < 20; i++ {
+		require.NoError(net.Step(time.Second))
+	}
+	net.Partition([]enode.ID{testNodeID(1), testNodeID(2)})
+	for i := 0; i < 20; i++ {
+		require.NoError(net.Step(time.Second))
+	}
+	net.Heal()
+	for _, node := range nodes {
+		node.stopped = true
+	}
+	total := 0
+	for _, node := range nodes {
+		total += int(node.seq)
+	}
+	require.NoError(net.RunUntil(time.Second, func() bool {
+		for _, node := range nodes {
+			if len(node.known) != total {
+				return false
+			}
+		}
+		return true
+	}, 1000))
+	return nodes
+}
+
+func TestNetwork(t *testing.T) {
+	require := require.New(t)
+
+	nodes := runTestNetwork(t, 1)
+	for _, node := range nodes {
+		require.Len(node.blocks, len(node.known))
+		require.Empty(node.pending)
+	}
+
+	// the run is reproducible from the seed
+	again := runTestNetwork(t, 1)
+	for i, node := range nodes {
+		require.Equal(node.blocks, again[i].blocks)
+	}
+}
+
+type idleNode struct{}
+
+func (idleNode) Step([]*inter.EventPayload) ([]*inter.EventPayload, error) {
+	return nil, nil
+}
+
+func TestClock(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1608600000, 0)
+	net := New(start, Config{
+		Seed:         1,
+		MaxClockSkew: time.Second,
+	})
+	clock := net.NewClock()
+	require.True(clock.Skew() >= -time.Second && clock.Skew() <= time.Second)
+	net.Add(enode.ID{1}, idleNode{}, clock)
+	require.True(start.Add(clock.Skew()).Equal(clock.Now()))
+
+	require.NoError(net.Step(time.Minute))
+	require.True(start.Add(time.Minute).Equal(net.Now()))
+	require.True(net.Now().Add(clock.Skew()).Equal(clock.Now()))
+}
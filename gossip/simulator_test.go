@@ -0,0 +1,196 @@
+package gossip
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/gossip/simnet"
+	"github.com/Fantom-foundation/go-opera/integration/makefakegenesis"
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// simNode is a node of the simulated network, which runs a single validator's emitter
+type simNode struct {
+	*testEnv
+	id      idx.ValidatorID
+	clock   *simnet.Clock
+	pending []*inter.EventPayload
+}
+
+func simNodeID(id idx.ValidatorID) enode.ID {
+	return enode.ID{byte(id >> 8), byte(id)}
+}
+
+// Step connects the received events and emits an event at the node's time
+func (node *simNode) Step(received []*inter.EventPayload) ([]*inter.EventPayload, error) {
+	node.pending = append(node.pending, received...)
+	if err := node.connectPending(); err != nil {
+		return nil, err
+	}
+	node.WaitBlockEnd()
+
+	node.t = node.clock.Now()
+	var emitted []*inter.EventPayload
+	for _, em := range node.emitters {
+		e, err := em.EmitEvent()
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			emitted = append(emitted, e)
+		}
+	}
+	node.WaitBlockEnd()
+	return emitted, nil
+}
+
+// connectPending processes the received events which have all the parents connected
+func (node *simNode) connectPending() error {
+	for progress := true; progress; {
+		progress = false
+		rest := node.pending[:0]
+		for _, e := range node.pending {
+			if node.store.HasEvent(e.ID()) || e.Epoch() < node.store.GetEpoch() {
+				continue
+			}
+			if e.Epoch() > node.store.GetEpoch() || !node.hasParents(e) {
+				rest = append(rest, e)
+				continue
+			}
+			node.engineMu.Lock()
+			err := node.processEvent(e)
+			node.engineMu.Unlock()
+			if err != nil {
+				return err
+			}
+			progress = true
+		}
+		node.pending = rest
+	}
+	return nil
+}
+
+func (node *simNode) hasParents(e *inter.EventPayload) bool {
+	for _, p := range e.Parents() {
+		if !node.store.HasEvent(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// simNetwork is a network of validators, simulated by simnet
+type simNetwork struct {
+	*simnet.Network
+	nodes []*simNode
+}
+
+func newSimNetwork(validatorsNum idx.Validator, cfg simnet.Config) *simNetwork {
+	n := &simNetwork{
+		Network: simnet.New(makefakegenesis.FakeGenesisTime.Time(), cfg),
+	}
+	for i := idx.Validator(0); i < validatorsNum; i++ {
+		id := idx.ValidatorID(i + 1)
+		clock := n.NewClock()
+		env := newTestEnvWithClock(1, validatorsNum, func(vid idx.ValidatorID) bool {
+			return vid == id
+		}, clock)
+		node := &simNode{
+			testEnv: env,
+			id:      id,
+			clock:   clock,
+		}
+		n.nodes = append(n.nodes, node)
+		n.Add(simNodeID(id), node, clock)
+	}
+	return n
+}
+
+func (n *simNetwork) Close() {
+	for _, node := range n.nodes {
+		node.Close()
+	}
+}
+
+// Partition splits validators into the groups
+func (n *simNetwork) Partition(groups ...[]idx.ValidatorID) {
+	nodeGroups := make([][]enode.ID, len(groups))
+	for i, group := range groups {
+		for _, id := range group {
+			nodeGroups[i] = append(nodeGroups[i], simNodeID(id))
+		}
+	}
+	n.Network.Partition(nodeGroups...)
+}
+
+func (n *simNetwork) minBlock(nodes ...*simNode) idx.Block {
+	if len(nodes) == 0 {
+		nodes = n.nodes
+	}
+	min := nodes[0].store.GetLatestBlockIndex()
+	for _, node := range nodes[1:] {
+		if b := node.store.GetLatestBlockIndex(); b < min {
+			min = b
+		}
+	}
+	return min
+}
+
+// checkAgreement checks that all the nodes have decided the same blocks
+func (n *simNetwork) checkAgreement() error {
+	upTo := n.minBlock()
+	for b := idx.Block(1); b <= upTo; b++ {
+		expected := n.nodes[0].store.GetBlock(b)
+		for _, node := range n.nodes[1:] {
+			got := node.store.GetBlock(b)
+			if (expected == nil) != (got == nil) || (got != nil && got.Atropos != expected.Atropos) {
+				return fmt.Errorf("validator %d disagrees on block %d", node.id, b)
+			}
+		}
+	}
+	return nil
+}
+
+func TestSimNetworkAgreement(t *testing.T) {
+	require := require.New(t)
+
+	net := newSimNetwork(4, simnet.Config{
+		Seed: 1,
+		Faults: simnet.Faults{
+			DropRate: 0.05,
+			MinDelay: 100 * time.Millisecond,
+			MaxDelay: 3 * time.Second,
+		},
+		MaxClockSkew:  500 * time.Millisecond,
+		RetryInterval: 2 * time.Second,
+	})
+	defer net.Close()
+
+	start := net.minBlock()
+	require.NoError(net.RunUntil(time.Second, func() bool {
+		return net.minBlock() >= start+5
+	}, 1000))
+	require.NoError(net.checkAgreement())
+
+	// the majority keeps deciding blocks while the minority is cut off
+	net.Partition([]idx.ValidatorID{1, 2, 3}, []idx.ValidatorID{4})
+	majority := net.nodes[:3]
+	before := net.minBlock(majority...)
+	require.NoError(net.RunUntil(time.Second, func() bool {
+		return net.minBlock(majority...) >= before+5
+	}, 1000))
+	require.NoError(net.checkAgreement())
+
+	// the minority catches up after healing
+	net.Heal()
+	target := net.minBlock(majority...)
+	require.NoError(net.RunUntil(time.Second, func() bool {
+		return net.minBlock() >= target
+	}, 1000))
+	require.NoError(net.checkAgreement())
+}
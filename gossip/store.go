@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Fantom-foundation/lachesis-base/common/bigendian"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
 	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/flushable"
 	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
@@ -36,12 +37,19 @@ type Store struct {
 		BlockEpochState        kvdb.Store `table:"D"`
 		BlockEpochStateHistory kvdb.Store `table:"h"`
 		Events                 kvdb.Store `table:"e"`
+		EventHeaders           kvdb.Store `table:"E"`
+		CreatorEvents          kvdb.Store `table:"C"`
+		EpochStats             kvdb.Store `table:"S"`
 		Blocks                 kvdb.Store `table:"b"`
 		EpochBlocks            kvdb.Store `table:"P"`
 		Genesis                kvdb.Store `table:"g"`
 
 		// P2P-only
 		HighestLamport kvdb.Store `table:"l"`
+		PeerPenalties  kvdb.Store `table:"p"`
+
+		// Retention and indexing watermarks
+		Retention kvdb.Store `table:"R"`
 
 		// Network version
 		NetworkVersion kvdb.Store `table:"V"`
@@ -83,6 +91,12 @@ type Store struct {
 		WriteLlrState sync.Mutex
 	}
 
+	// epochStats keeps statistics of the recent epochs, which are written into DB on Commit
+	epochStats struct {
+		sync.Mutex
+		recent map[idx.Epoch]*epochStatsEntry
+	}
+
 	rlp rlpstore.Helper
 
 	logger.Instance
@@ -132,7 +146,7 @@ func (s *Store) initCache() {
 	blockHashesCacheSize := nominalSize * uint(blockHashesNum)
 	s.cache.BlockHashes = s.makeCache(blockHashesCacheSize, blockHashesNum)
 
-	eventsHeadersNum := s.cfg.Cache.EventsNum
+	eventsHeadersNum := s.cfg.Cache.EventsHeadersNum
 	eventsHeadersCacheSize := nominalSize * uint(eventsHeadersNum)
 	s.cache.EventsHeaders = s.makeCache(eventsHeadersCacheSize, eventsHeadersNum)
 
@@ -196,6 +210,7 @@ func (s *Store) Commit() error {
 	s.FlushLastBVs()
 	s.FlushLastEV()
 	s.FlushLlrState()
+	s.FlushEpochEventsStats()
 	es := s.getAnyEpochStore()
 	if es != nil {
 		es.FlushHeads()
@@ -0,0 +1,106 @@
+package gossip
+
+import (
+	"fmt"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/utils/concurrent"
+)
+
+// epochIndexes is DAG indexes of an epoch, calculated from raw events
+type epochIndexes struct {
+	heads hash.EventsSet
+	lasts map[idx.ValidatorID]*inter.Event
+}
+
+// calcEpochIndexes validates stored events of the epoch and calculates the DAG indexes from them
+func (s *Store) calcEpochIndexes(epoch idx.Epoch) (*epochIndexes, error) {
+	res := &epochIndexes{
+		heads: hash.EventsSet{},
+		lasts: make(map[idx.ValidatorID]*inter.Event),
+	}
+
+	it := s.table.Events.NewIterator(epoch.Bytes(), nil)
+	defer it.Release()
+	for it.Next() {
+		key := hash.BytesToEvent(it.Key())
+		e := &inter.EventPayload{}
+		if err := rlp.DecodeBytes(it.Value(), e); err != nil {
+			return nil, fmt.Errorf("failed to decode event %s: %v", key.String(), err)
+		}
+		if e.ID() != key {
+			return nil, fmt.Errorf("event %s is stored with wrong key %s", e.ID().String(), key.String())
+		}
+		for _, p := range e.Parents() {
+			// events are iterated in lamport order, so parents are met before children
+			if !s.HasEvent(p) {
+				return nil, fmt.Errorf("parent %s of event %s is missing", p.String(), e.ID().String())
+			}
+			delete(res.heads, p)
+		}
+		res.heads.Add(e.ID())
+		if last := res.lasts[e.Creator()]; last == nil || last.Seq() < e.Seq() {
+			res.lasts[e.Creator()] = &e.Event
+		}
+	}
+	return res, it.Error()
+}
+
+// CheckConsistency validates stored events of the epoch (hashes and parents presence),
+// and the heads and last events indexes if epoch is the current one.
+func (s *Store) CheckConsistency(epoch idx.Epoch) error {
+	indexes, err := s.calcEpochIndexes(epoch)
+	if err != nil {
+		return err
+	}
+	if epoch != s.GetEpoch() {
+		return nil
+	}
+
+	heads := s.GetHeadsSlice(epoch)
+	if len(heads) != len(indexes.heads) {
+		return fmt.Errorf("heads index has %d events, expected %d", len(heads), len(indexes.heads))
+	}
+	for _, h := range heads {
+		if !indexes.heads.Contains(h) {
+			return fmt.Errorf("event %s isn't a head", h.String())
+		}
+	}
+
+	lasts := s.GetLastEvents(epoch)
+	lasts.RLock()
+	defer lasts.RUnlock()
+	if len(lasts.Val) != len(indexes.lasts) {
+		return fmt.Errorf("last events index has %d validators, expected %d", len(lasts.Val), len(indexes.lasts))
+	}
+	for creator, id := range lasts.Val {
+		expected := indexes.lasts[creator]
+		got := s.GetEvent(id)
+		// forks may have the same seq, so compare only seq
+		if expected == nil || got == nil || expected.Seq() != got.Seq() {
+			return fmt.Errorf("last event %s of validator %d is wrong", id.String(), creator)
+		}
+	}
+	return nil
+}
+
+// RepairIndexes rebuilds the heads and last events indexes of the current epoch from raw events.
+func (s *Store) RepairIndexes() error {
+	epoch := s.GetEpoch()
+	indexes, err := s.calcEpochIndexes(epoch)
+	if err != nil {
+		return err
+	}
+
+	lasts := make(map[idx.ValidatorID]hash.Event, len(indexes.lasts))
+	for creator, e := range indexes.lasts {
+		lasts[creator] = e.ID()
+	}
+	s.SetHeads(epoch, concurrent.WrapEventsSet(indexes.heads))
+	s.SetLastEvents(epoch, concurrent.WrapValidatorEventsSet(lasts))
+	return s.Commit()
+}
@@ -0,0 +1,58 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/dag"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+func creatorEventsPrefix(epoch idx.Epoch, creator idx.ValidatorID) []byte {
+	return append(epoch.Bytes(), creator.Bytes()...)
+}
+
+// SetCreatorEvent indexes event by its creator and seq.
+func (s *Store) SetCreatorEvent(e dag.Event) {
+	key := append(creatorEventsPrefix(e.Epoch(), e.Creator()), e.Seq().Bytes()...)
+	key = append(key, e.ID().Bytes()...)
+
+	if err := s.table.CreatorEvents.Put(key, []byte{}); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// getCreatorEventsIndexed returns the epoch since which events are indexed by creator.
+// Events of the prior epochs aren't indexed if the DB was migrated from a version without the index
+func (s *Store) getCreatorEventsIndexed() idx.Epoch {
+	return s.getRetentionWatermark([]byte("c"))
+}
+
+func (s *Store) setCreatorEventsIndexed(epoch idx.Epoch) {
+	s.setRetentionWatermark([]byte("c"), epoch)
+}
+
+// ForEachEventByCreator iterates IDs of the creator's events starting from fromSeq, in seq order.
+// Forks are iterated too.
+func (s *Store) ForEachEventByCreator(epoch idx.Epoch, creator idx.ValidatorID, fromSeq idx.Event, onEvent func(seq idx.Event, id hash.Event) bool) {
+	it := s.table.CreatorEvents.NewIterator(creatorEventsPrefix(epoch, creator), fromSeq.Bytes())
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		seq := idx.BytesToEvent(key[8:12])
+		if !onEvent(seq, hash.BytesToEvent(key[12:])) {
+			return
+		}
+	}
+}
+
+// GetEventsByCreator returns IDs of the creator's events within [fromSeq, toSeq] range.
+func (s *Store) GetEventsByCreator(epoch idx.Epoch, creator idx.ValidatorID, fromSeq, toSeq idx.Event) hash.Events {
+	var res hash.Events
+	s.ForEachEventByCreator(epoch, creator, fromSeq, func(seq idx.Event, id hash.Event) bool {
+		if seq > toSeq {
+			return false
+		}
+		res = append(res, id)
+		return true
+	})
+	return res
+}
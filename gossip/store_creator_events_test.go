@@ -0,0 +1,35 @@
+package gossip
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStoreRecoverCreatorEvents(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+		require.NoError(err)
+	}
+	current := env.store.GetEpoch()
+	require.NotEmpty(env.store.GetEventsByCreator(current-2, 1, 0, math.MaxUint32))
+
+	// drop the index as if the DB was created by a version without it
+	require.NoError(pruneEpochKeys(env.store.table.CreatorEvents, 0, current+1, nil))
+	require.NoError(env.store.recoverCreatorEvents())
+
+	// only the current and the last sealed epochs are indexed
+	require.Equal(current-1, env.store.getCreatorEventsIndexed())
+	require.NotEmpty(env.store.GetEventsByCreator(current-1, 1, 0, math.MaxUint32))
+	require.Empty(env.store.GetEventsByCreator(current-2, 1, 0, math.MaxUint32))
+}
@@ -0,0 +1,137 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// recentEpochStats is the number of recent epochs whose statistics are kept in memory.
+// Txs of the last sealed epoch may be still executed when events of the next epoch arrive
+const recentEpochStats = 2
+
+// ValidatorEventsStats is statistics of validator's events within an epoch
+type ValidatorEventsStats struct {
+	Validator idx.ValidatorID
+	Events    idx.Event
+	// GasPowerUsed is the gas power consumed by the events
+	GasPowerUsed uint64
+	// GasUsed is the gas used by the executed txs, which were originated in the events
+	GasUsed uint64
+	Size    uint64
+}
+
+// EpochEventsStats is statistics of events within an epoch, updated incrementally on each connected event
+type EpochEventsStats struct {
+	Epoch      idx.Epoch
+	Validators []ValidatorEventsStats
+	MaxFrame   idx.Frame
+}
+
+type epochStatsEntry struct {
+	stats     EpochEventsStats
+	positions map[idx.ValidatorID]int
+	dirty     bool
+}
+
+func (e *epochStatsEntry) validator(id idx.ValidatorID) *ValidatorEventsStats {
+	i, ok := e.positions[id]
+	if !ok {
+		i = len(e.stats.Validators)
+		e.positions[id] = i
+		e.stats.Validators = append(e.stats.Validators, ValidatorEventsStats{Validator: id})
+	}
+	return &e.stats.Validators[i]
+}
+
+func (e *epochStatsEntry) copy() *EpochEventsStats {
+	cp := e.stats
+	cp.Validators = append(make([]ValidatorEventsStats, 0, len(e.stats.Validators)), e.stats.Validators...)
+	return &cp
+}
+
+// getEpochStatsEntry returns the in-memory statistics of the epoch, and evicts the older epochs.
+// Must be called under epochStats lock
+func (s *Store) getEpochStatsEntry(epoch idx.Epoch) *epochStatsEntry {
+	if entry, ok := s.epochStats.recent[epoch]; ok {
+		return entry
+	}
+	entry := &epochStatsEntry{
+		stats:     EpochEventsStats{Epoch: epoch},
+		positions: make(map[idx.ValidatorID]int),
+	}
+	if stored, _ := s.rlp.Get(s.table.EpochStats, epoch.Bytes(), &EpochEventsStats{}).(*EpochEventsStats); stored != nil {
+		entry.stats = *stored
+		for i, v := range stored.Validators {
+			entry.positions[v.Validator] = i
+		}
+	}
+	if s.epochStats.recent == nil {
+		s.epochStats.recent = make(map[idx.Epoch]*epochStatsEntry)
+	}
+	s.epochStats.recent[epoch] = entry
+	for old, oldEntry := range s.epochStats.recent {
+		if old+recentEpochStats <= epoch {
+			s.flushEpochEventsStats(oldEntry)
+			delete(s.epochStats.recent, old)
+		}
+	}
+	return entry
+}
+
+// GetEpochEventsStats returns the events statistics of the epoch.
+func (s *Store) GetEpochEventsStats(epoch idx.Epoch) *EpochEventsStats {
+	s.epochStats.Lock()
+	defer s.epochStats.Unlock()
+	if entry, ok := s.epochStats.recent[epoch]; ok {
+		return entry.copy()
+	}
+	stats, _ := s.rlp.Get(s.table.EpochStats, epoch.Bytes(), &EpochEventsStats{}).(*EpochEventsStats)
+	return stats
+}
+
+// updateEpochEventsStats adds the connected event into the events statistics of its epoch.
+func (s *Store) updateEpochEventsStats(e *inter.EventPayload) {
+	s.epochStats.Lock()
+	defer s.epochStats.Unlock()
+	entry := s.getEpochStatsEntry(e.Epoch())
+	if e.Frame() > entry.stats.MaxFrame {
+		entry.stats.MaxFrame = e.Frame()
+	}
+	v := entry.validator(e.Creator())
+	v.Events++
+	v.GasPowerUsed += e.GasPowerUsed()
+	v.Size += uint64(e.Size())
+	entry.dirty = true
+}
+
+// addEpochTxsGasUsed adds the gas used by executed txs into the statistics of the txs originators.
+func (s *Store) addEpochTxsGasUsed(epoch idx.Epoch, gasUsed map[idx.ValidatorID]uint64) {
+	if len(gasUsed) == 0 {
+		return
+	}
+	s.epochStats.Lock()
+	defer s.epochStats.Unlock()
+	entry := s.getEpochStatsEntry(epoch)
+	for creator, gas := range gasUsed {
+		entry.validator(creator).GasUsed += gas
+	}
+	entry.dirty = true
+}
+
+// FlushEpochEventsStats writes the in-memory epochs statistics into DB.
+func (s *Store) FlushEpochEventsStats() {
+	s.epochStats.Lock()
+	defer s.epochStats.Unlock()
+	for _, entry := range s.epochStats.recent {
+		s.flushEpochEventsStats(entry)
+	}
+}
+
+func (s *Store) flushEpochEventsStats(entry *epochStatsEntry) {
+	if !entry.dirty {
+		return
+	}
+	s.rlp.Set(s.table.EpochStats, entry.stats.Epoch.Bytes(), &entry.stats)
+	entry.dirty = false
+}
@@ -0,0 +1,55 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStoreEpochEventsStats(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	_, err := env.ApplyTxs(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	require.NoError(err)
+
+	epoch := env.store.GetEpoch()
+	stats := env.store.GetEpochEventsStats(epoch)
+	require.NotNil(stats)
+	require.Equal(epoch, stats.Epoch)
+	require.NotZero(stats.MaxFrame)
+
+	var events, gasUsed uint64
+	for _, v := range stats.Validators {
+		require.NotZero(v.Events)
+		require.NotZero(v.Size)
+		events += uint64(v.Events)
+		gasUsed += v.GasUsed
+	}
+	require.NotZero(events)
+	require.Equal(params.TxGas, gasUsed)
+
+	// the statistics are written into DB only on commit
+	stored := func() *EpochEventsStats {
+		s, _ := env.store.rlp.Get(env.store.table.EpochStats, epoch.Bytes(), &EpochEventsStats{}).(*EpochEventsStats)
+		return s
+	}
+	env.store.FlushEpochEventsStats()
+	require.Equal(stats, stored())
+
+	// the statistics are pruned with events
+	_, err = env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	require.NoError(err)
+	_, err = env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+	require.NoError(err)
+	env.store.FlushEpochEventsStats()
+	require.NoError(env.store.PruneEpochs(epoch+1, epoch+1))
+	require.Nil(stored())
+}
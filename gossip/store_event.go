@@ -9,12 +9,21 @@ import (
 
 	"github.com/Fantom-foundation/lachesis-base/hash"
 	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/Fantom-foundation/go-opera/inter"
 )
 
+var (
+	eventsCacheHitMeter         = metrics.GetOrRegisterMeter("gossip/cache/events/hit", nil)
+	eventsCacheMissMeter        = metrics.GetOrRegisterMeter("gossip/cache/events/miss", nil)
+	eventsHeadersCacheHitMeter  = metrics.GetOrRegisterMeter("gossip/cache/eventheaders/hit", nil)
+	eventsHeadersCacheMissMeter = metrics.GetOrRegisterMeter("gossip/cache/eventheaders/miss", nil)
+)
+
 // DelEvent deletes event.
 func (s *Store) DelEvent(id hash.Event) {
 	key := id.Bytes()
@@ -45,8 +54,10 @@ func (s *Store) SetEvent(e *inter.EventPayload) {
 func (s *Store) GetEventPayload(id hash.Event) *inter.EventPayload {
 	// Get event from LRU cache first.
 	if ev, ok := s.cache.Events.Get(id); ok {
+		eventsCacheHitMeter.Mark(1)
 		return ev.(*inter.EventPayload)
 	}
+	eventsCacheMissMeter.Mark(1)
 
 	key := id.Bytes()
 	w, _ := s.rlp.Get(s.table.Events, key, &inter.EventPayload{}).(*inter.EventPayload)
@@ -69,13 +80,15 @@ func (s *Store) GetEventPayload(id hash.Event) *inter.EventPayload {
 func (s *Store) GetEvent(id hash.Event) *inter.Event {
 	// Get event from LRU cache first.
 	if ev, ok := s.cache.EventsHeaders.Get(id); ok {
+		eventsHeadersCacheHitMeter.Mark(1)
 		return ev.(*inter.Event)
 	}
+	eventsHeadersCacheMissMeter.Mark(1)
 
 	key := id.Bytes()
 	w, _ := s.rlp.Get(s.table.Events, key, &inter.EventPayload{}).(*inter.EventPayload)
 	if w == nil {
-		return nil
+		return s.getEventHeader(id)
 	}
 	fixEventTxHashes(w)
 
@@ -88,6 +101,122 @@ func (s *Store) GetEvent(id hash.Event) *inter.Event {
 	return &eh
 }
 
+// getEventHeader returns the kept header of a pruned event.
+func (s *Store) getEventHeader(id hash.Event) *inter.Event {
+	b, err := s.table.EventHeaders.Get(id.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if b == nil {
+		return nil
+	}
+	eh := &inter.Event{}
+	if err := eh.UnmarshalBinary(b); err != nil {
+		s.Log.Crit("Failed to decode event header", "err", err)
+	}
+
+	// Put event header to LRU cache.
+	s.cache.EventsHeaders.Add(id, eh, nominalSize)
+
+	return eh
+}
+
+// setEventHeader keeps the header of an event which is being pruned.
+func (s *Store) setEventHeader(e *inter.Event) {
+	b, err := e.MarshalBinary()
+	if err != nil {
+		s.Log.Crit("Failed to encode event header", "err", err)
+	}
+	if err := s.table.EventHeaders.Put(e.ID().Bytes(), b); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// PruneEpochs deletes events of all the epochs before the specified one.
+// Events with txs are kept, because blocks refer to them to get the block txs.
+// Headers of the deleted events are kept if their epoch isn't lower than headersFrom.
+func (s *Store) PruneEpochs(before, headersFrom idx.Epoch) error {
+	from := s.getPrunedEpochs()
+	if before <= from {
+		return nil
+	}
+	err := pruneEpochKeys(s.table.Events, from, before, func(key, val []byte) bool {
+		e := &inter.EventPayload{}
+		if err := rlp.DecodeBytes(val, e); err != nil {
+			s.Log.Crit("Failed to decode event", "err", err)
+		}
+		if e.AnyTxs() {
+			return false
+		}
+		if e.Epoch() >= headersFrom {
+			s.setEventHeader(&e.Event)
+		}
+		id := hash.BytesToEvent(key)
+		s.cache.Events.Remove(id)
+		s.cache.EventsHeaders.Remove(id)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	err = pruneEpochKeys(s.table.CreatorEvents, from, before, nil)
+	if err != nil {
+		return err
+	}
+	err = pruneEpochKeys(s.table.EpochStats, from, before, nil)
+	if err != nil {
+		return err
+	}
+	s.setPrunedEpochs(before)
+	return nil
+}
+
+// PruneEventHeaders deletes the kept headers of pruned events of all the epochs before the specified one.
+func (s *Store) PruneEventHeaders(before idx.Epoch) error {
+	from := s.getPrunedEventHeaders()
+	if before <= from {
+		return nil
+	}
+	err := pruneEpochKeys(s.table.EventHeaders, from, before, func(key, _ []byte) bool {
+		s.cache.EventsHeaders.Remove(hash.BytesToEvent(key))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	s.setPrunedEventHeaders(before)
+	return nil
+}
+
+// pruneEpochKeys deletes the epoch-prefixed keys within [from, before) epochs, for which shouldDelete returns true.
+// A nil shouldDelete deletes all the keys
+func pruneEpochKeys(t kvdb.Store, from, before idx.Epoch, shouldDelete func(key, val []byte) bool) error {
+	it := t.NewIterator(nil, from.Bytes())
+	defer it.Release()
+	batch := t.NewBatch()
+	defer batch.Reset()
+	for it.Next() {
+		if bytes.Compare(it.Key(), before.Bytes()) >= 0 {
+			break
+		}
+		if shouldDelete != nil && !shouldDelete(it.Key(), it.Value()) {
+			continue
+		}
+		err := batch.Delete(it.Key())
+		if err != nil {
+			return err
+		}
+		if batch.ValueSize() > kvdb.IdealBatchSize {
+			err := batch.Write()
+			if err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	return batch.Write()
+}
+
 func (s *Store) forEachEvent(it ethdb.Iterator, onEvent func(event *inter.EventPayload) bool) {
 	for it.Next() {
 		event := &inter.EventPayload{}
@@ -0,0 +1,108 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStorePruneEpochs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	var blocks []idx.Block
+	for i := 0; i < 3; i++ {
+		rr, err := env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+		require.NoError(err)
+		blocks = append(blocks, idx.Block(rr[0].BlockNumber.Uint64()))
+	}
+
+	before := env.store.GetEpoch()
+	require.NoError(env.store.PruneEpochs(before, before))
+	// the pruned epochs aren't rescanned after a restart
+	require.Equal(before, env.store.getPrunedEpochs())
+
+	// only events with txs are left in the pruned epochs
+	withTxs := 0
+	env.store.ForEachEvent(0, func(e *inter.EventPayload) bool {
+		if e.Epoch() >= before {
+			return false
+		}
+		require.True(e.AnyTxs())
+		withTxs++
+		return true
+	})
+	require.NotZero(withTxs)
+
+	// events of blocks are kept
+	for _, n := range blocks {
+		block := env.store.GetBlock(n)
+		require.NotNil(block)
+		require.NotEmpty(block.Events)
+		for _, id := range block.Events {
+			require.True(env.store.HasEvent(id))
+		}
+	}
+}
+
+func TestStorePruneEventHeaders(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+		require.NoError(err)
+	}
+
+	before := env.store.GetEpoch()
+	var pruned hash.Events
+	env.store.ForEachEvent(0, func(e *inter.EventPayload) bool {
+		if e.Epoch() >= before {
+			return false
+		}
+		if !e.AnyTxs() {
+			pruned = append(pruned, e.ID())
+		}
+		return true
+	})
+	require.NotEmpty(pruned)
+
+	// headers of the pruned events are kept
+	env.store.cfg.Retention.KeepEventsEpochs = 2
+	env.store.cfg.Retention.KeepEventHeadersEpochs = before - 2
+	require.Equal(before-2, env.store.firstEventsEpoch())
+	require.Equal(idx.Epoch(0), env.store.firstEventHeadersEpoch())
+	require.NoError(env.store.PruneHistory())
+	for _, id := range pruned {
+		if id.Epoch() >= before-2 {
+			continue
+		}
+		require.Nil(env.store.GetEventPayload(id))
+		e := env.store.GetEvent(id)
+		require.NotNil(e)
+		require.Equal(id, e.ID())
+	}
+
+	// the headers are pruned when they are out of the retention
+	env.store.cfg.Retention.KeepEventHeadersEpochs = 0
+	require.Equal(before-2, env.store.firstEventHeadersEpoch())
+	require.NoError(env.store.PruneHistory())
+	require.Equal(before-2, env.store.getPrunedEventHeaders())
+	for _, id := range pruned {
+		if id.Epoch() < before-2 {
+			require.Nil(env.store.GetEvent(id))
+		}
+	}
+}
@@ -89,6 +89,9 @@ func (s *Store) GetLlrBlockResult(block idx.Block) *hash.Hash {
 }
 
 func (s *Store) GetFullBlockRecord(n idx.Block) *ibr.LlrFullBlockRecord {
+	if n < s.firstFullBlockRecord() {
+		return nil
+	}
 	block := s.GetBlock(n)
 	if block == nil {
 		return nil
@@ -136,6 +139,10 @@ type LlrIdxFullBlockRecordRLP struct {
 var emptyReceiptsRLP, _ = rlp.EncodeToBytes([]*types.ReceiptForStorage{})
 
 func (s *Store) IterateFullBlockRecordsRLP(start idx.Block, f func(b idx.Block, br rlp.RawValue) bool) {
+	// records of the blocks with pruned receipts aren't served
+	if first := s.firstFullBlockRecord(); start < first {
+		start = first
+	}
 	it := s.table.Blocks.NewIterator(nil, start.Bytes())
 	defer it.Release()
 	for it.Next() {
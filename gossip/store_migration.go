@@ -48,7 +48,8 @@ func (s *Store) migrations() *migration.Migration {
 		Next("LlrState recovery", s.recoverLlrState).
 		Next("erase gossip-async db", s.eraseGossipAsyncDB).
 		Next("erase SFC API table", s.eraseSfcApiTable).
-		Next("erase legacy genesis DB", s.eraseGenesisDB)
+		Next("erase legacy genesis DB", s.eraseGenesisDB).
+		Next("creator events index", s.recoverCreatorEvents)
 }
 
 func unsupportedMigration() error {
@@ -200,6 +201,26 @@ func (s *Store) recoverHeadsStorage() error {
 	return nil
 }
 
+// recoverCreatorEvents indexes by creator only the events of the current and the last sealed epochs,
+// to not scan the whole history at startup. Events of the older epochs are left unindexed
+func (s *Store) recoverCreatorEvents() error {
+	from := s.GetEpoch()
+	if from > 1 {
+		from--
+	}
+	s.setCreatorEventsIndexed(from)
+	var err error
+	s.ForEachEvent(from, func(e *inter.EventPayload) bool {
+		s.SetCreatorEvent(e)
+		// flush periodically to not keep the whole index in memory
+		if s.dbs.NotFlushedSizeEst() > s.cfg.MaxNonFlushedSize {
+			err = s.flushDBs()
+		}
+		return err == nil
+	})
+	return err
+}
+
 func (s *Store) recoverLastEventsStorage() error {
 	s.loadEpochStore(s.GetEpoch())
 	es := s.getEpochStore(s.GetEpoch())
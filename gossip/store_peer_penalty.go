@@ -0,0 +1,26 @@
+package gossip
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+// SetPeerBan stores the time until which the peer is banned, it's kept across reconnections and restarts.
+func (s *Store) SetPeerBan(id enode.ID, bannedUntil inter.Timestamp) {
+	if err := s.table.PeerPenalties.Put(id.Bytes(), bannedUntil.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// GetPeerBan returns the time until which the peer is banned.
+func (s *Store) GetPeerBan(id enode.ID) inter.Timestamp {
+	b, err := s.table.PeerPenalties.Get(id.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if len(b) != 8 {
+		return 0
+	}
+	return inter.BytesToTimestamp(b)
+}
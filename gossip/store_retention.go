@@ -0,0 +1,133 @@
+package gossip
+
+import (
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+)
+
+// minKeepEventsEpochs protects events of the current and the last sealed epochs,
+// which are still needed by the consensus engine, emitters and syncing peers
+const minKeepEventsEpochs = 2
+
+// historyBounds are the lowest kept epochs and block of the historical data.
+// Zero values mean that nothing is pruned
+type historyBounds struct {
+	eventsEpoch  idx.Epoch
+	headersEpoch idx.Epoch
+	block        idx.Block
+}
+
+// PruneHistory deletes historical data according to the retention config.
+func (s *Store) PruneHistory() error {
+	return s.pruneHistory(s.historyToPrune())
+}
+
+// historyToPrune returns the lowest epochs whose events and event headers are kept and the lowest block whose receipts are kept.
+func (s *Store) historyToPrune() historyBounds {
+	return historyBounds{
+		eventsEpoch:  s.firstEventsEpoch(),
+		headersEpoch: s.firstEventHeadersEpoch(),
+		block:        s.firstFullBlockRecord(),
+	}
+}
+
+// pruneHistory deletes events, event headers and receipts prior to the bounds.
+// It touches only the data which isn't used by events and blocks processing, so it may run concurrently with them
+func (s *Store) pruneHistory(bounds historyBounds) error {
+	if bounds.eventsEpoch != 0 {
+		if err := s.PruneEpochs(bounds.eventsEpoch, bounds.headersEpoch); err != nil {
+			return err
+		}
+	}
+	if bounds.headersEpoch != 0 {
+		if err := s.PruneEventHeaders(bounds.headersEpoch); err != nil {
+			return err
+		}
+	}
+	if bounds.block != 0 {
+		if err := s.evm.PruneReceipts(bounds.block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPrunedEpochs returns the epoch before which events are already pruned
+func (s *Store) getPrunedEpochs() idx.Epoch {
+	return s.getRetentionWatermark([]byte("e"))
+}
+
+func (s *Store) setPrunedEpochs(epoch idx.Epoch) {
+	s.setRetentionWatermark([]byte("e"), epoch)
+}
+
+// getPrunedEventHeaders returns the epoch before which event headers are already pruned
+func (s *Store) getPrunedEventHeaders() idx.Epoch {
+	return s.getRetentionWatermark([]byte("h"))
+}
+
+func (s *Store) setPrunedEventHeaders(epoch idx.Epoch) {
+	s.setRetentionWatermark([]byte("h"), epoch)
+}
+
+func (s *Store) getRetentionWatermark(key []byte) idx.Epoch {
+	b, err := s.table.Retention.Get(key)
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	if b == nil {
+		return 0
+	}
+	return idx.BytesToEpoch(b)
+}
+
+func (s *Store) setRetentionWatermark(key []byte, epoch idx.Epoch) {
+	if err := s.table.Retention.Put(key, epoch.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+}
+
+// firstEventsEpoch returns the lowest epoch whose events aren't pruned.
+// Only the events with txs are kept in the prior epochs, and they aren't indexed by creator
+func (s *Store) firstEventsEpoch() idx.Epoch {
+	keep := s.cfg.Retention.KeepEventsEpochs
+	if keep == 0 {
+		return 0
+	}
+	if keep < minKeepEventsEpochs {
+		keep = minKeepEventsEpochs
+	}
+	if epoch := s.GetEpoch(); epoch > keep {
+		return epoch - keep
+	}
+	return 0
+}
+
+// firstEventHeadersEpoch returns the lowest epoch whose event headers aren't pruned.
+// The headers are kept for KeepEventHeadersEpochs epochs prior to the epochs with full events
+func (s *Store) firstEventHeadersEpoch() idx.Epoch {
+	first := s.firstEventsEpoch()
+	if keep := s.cfg.Retention.KeepEventHeadersEpochs; first > keep {
+		return first - keep
+	}
+	return 0
+}
+
+// firstFullBlockRecord returns the lowest block whose receipts aren't pruned,
+// the full records of the prior blocks can't be built anymore
+func (s *Store) firstFullBlockRecord() idx.Block {
+	keep := s.cfg.Retention.KeepReceiptsBlocks
+	last := s.GetLatestBlockIndex()
+	if keep == 0 || last <= keep {
+		return 0
+	}
+	first := last - keep + 1
+	// records of the undecided blocks are still needed for LLR votes
+	if lowest := s.GetLlrState().LowestBlockToDecide; first > lowest {
+		first = lowest
+	}
+	return first
+}
+
+func (s *Store) isPruningEnabled() bool {
+	return s.cfg.Retention.KeepEventsEpochs != 0 || s.cfg.Retention.KeepReceiptsBlocks != 0
+}
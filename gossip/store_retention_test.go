@@ -0,0 +1,46 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/logger"
+	"github.com/Fantom-foundation/go-opera/utils"
+)
+
+func TestStorePruneReceipts(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := env.ApplyTxs(sameEpoch, env.Transfer(1, 2, utils.ToFtm(1)))
+		require.NoError(err)
+	}
+
+	last := env.store.GetLatestBlockIndex()
+	env.store.cfg.Retention.KeepReceiptsBlocks = 2
+	// all the blocks are decided
+	env.store.ModifyLlrState(func(llrs *LlrState) {
+		llrs.LowestBlockToDecide = last + 1
+	})
+	first := env.store.firstFullBlockRecord()
+	require.Equal(last-1, first)
+	require.NoError(env.store.PruneHistory())
+
+	// records of the blocks with pruned receipts aren't built
+	require.Nil(env.store.GetFullBlockRecord(first - 1))
+	require.NotNil(env.store.GetFullBlockRecord(first))
+	var served []idx.Block
+	env.store.IterateFullBlockRecordsRLP(0, func(b idx.Block, _ rlp.RawValue) bool {
+		served = append(served, b)
+		return true
+	})
+	require.NotEmpty(served)
+	require.Equal(first, served[0])
+}
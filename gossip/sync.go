@@ -2,6 +2,7 @@ package gossip
 
 import (
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -27,8 +28,34 @@ const (
 const (
 	snapsyncMinEndAge   = 14 * 24 * time.Hour
 	snapsyncMaxStartAge = 6 * time.Hour
+	syncSpeedWindow     = time.Minute
 )
 
+// syncSpeed estimates the rate of blocks processing
+type syncSpeed struct {
+	since      time.Time
+	sinceBlock idx.Block
+	rate       float64 // blocks per second
+	mu         sync.Mutex
+}
+
+func (s syncStage) String() string {
+	switch s {
+	case ssSnaps:
+		return "snaps"
+	case ssEvmSnapGen:
+		return "evmsnapgen"
+	case ssEvents:
+		return "events"
+	default:
+		return "unknown"
+	}
+}
+
+func (ss *syncStatus) Stage() syncStage {
+	return syncStage(atomic.LoadUint32(&ss.stage))
+}
+
 func (ss *syncStatus) Is(s ...syncStage) bool {
 	self := &ss.stage
 	for _, v := range s {
@@ -63,6 +90,32 @@ func (ss *syncStatus) AcceptTxs() bool {
 	return ss.MaybeSynced() && ss.Is(ssEvents)
 }
 
+func (s *syncSpeed) update(block idx.Block, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.since.IsZero() || block < s.sinceBlock {
+		s.since = now
+		s.sinceBlock = block
+		return
+	}
+	elapsed := now.Sub(s.since)
+	if elapsed < syncSpeedWindow {
+		return
+	}
+	s.rate = float64(block-s.sinceBlock) / elapsed.Seconds()
+	s.since = now
+	s.sinceBlock = block
+}
+
+// Rate returns the estimated number of processed blocks per second
+func (s *syncSpeed) Rate() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rate
+}
+
 type txsync struct {
 	p     *peer
 	txids []common.Hash
@@ -176,6 +229,7 @@ func (h *handler) updateSnapsyncStage() {
 }
 
 func (h *handler) snapsyncStageTick() {
+	h.syncSpeed.update(h.store.GetBlockState().LastBlock.Idx, time.Now())
 	// check if existing snapsync process can be resulted
 	h.updateSnapsyncStage()
 	llrs := h.store.GetLlrState()
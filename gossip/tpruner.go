@@ -0,0 +1,42 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+)
+
+// PeriodicPruner periodically prunes historical data of the Store according to the retention config
+type PeriodicPruner struct {
+	period time.Duration
+	prune  func()
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func (c *PeriodicPruner) loop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.prune()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+func (c *PeriodicPruner) Start() {
+	if c.period <= 0 {
+		return
+	}
+	c.wg.Add(1)
+	go c.loop()
+}
+
+func (c *PeriodicPruner) Stop() {
+	close(c.quit)
+	c.wg.Wait()
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +24,8 @@ const (
 	// metricsGatheringInterval specifies the interval to retrieve leveldb database
 	// compaction, io and pause stats to report to the user.
 	metricsGatheringInterval = 3 * time.Second
+	// tableSizesRefreshRatio is the number of metrics gathering intervals between table size estimations
+	tableSizesRefreshRatio = 20
 )
 
 type DBProducerWithMetrics struct {
@@ -34,6 +37,19 @@ type DropableStoreWithMetrics struct {
 
 	diskReadMeter  metrics.Meter // Meter for measuring the effective amount of data read
 	diskWriteMeter metrics.Meter // Meter for measuring the effective amount of data written
+	diskSizeGauge  metrics.Gauge // Gauge for tracking the size of all the levels in the database
+
+	compTimeMeter  metrics.Meter // Meter for measuring the total time spent in database compaction
+	compReadMeter  metrics.Meter // Meter for measuring the data read during compaction
+	compWriteMeter metrics.Meter // Meter for measuring the data written during compaction
+
+	// latency timers are registered only if expensive metrics are enabled
+	getTimer    metrics.Timer // Timer for measuring the latency of reads
+	putTimer    metrics.Timer // Timer for measuring the latency of writes
+	deleteTimer metrics.Timer // Timer for measuring the latency of deletions
+
+	metricsPrefix   string
+	tableSizeGauges map[byte]metrics.Gauge // Gauges for tracking the estimated size of every table
 
 	quitLock sync.Mutex      // Mutex protecting the quit channel access
 	quitChan chan chan error // Quit channel to stop the metrics collection before closing the database
@@ -69,50 +85,72 @@ func (ds *DropableStoreWithMetrics) Close() error {
 	return ds.DropableStore.Close()
 }
 
+// Get retrieves the given key if it's present in the key-value data store.
+func (ds *DropableStoreWithMetrics) Get(key []byte) ([]byte, error) {
+	if ds.getTimer == nil {
+		return ds.DropableStore.Get(key)
+	}
+	defer ds.getTimer.UpdateSince(time.Now())
+	return ds.DropableStore.Get(key)
+}
+
+// Put inserts the given value into the key-value data store.
+func (ds *DropableStoreWithMetrics) Put(key []byte, value []byte) error {
+	if ds.putTimer == nil {
+		return ds.DropableStore.Put(key, value)
+	}
+	defer ds.putTimer.UpdateSince(time.Now())
+	return ds.DropableStore.Put(key, value)
+}
+
+// Delete removes the key from the key-value data store.
+func (ds *DropableStoreWithMetrics) Delete(key []byte) error {
+	if ds.deleteTimer == nil {
+		return ds.DropableStore.Delete(key)
+	}
+	defer ds.deleteTimer.UpdateSince(time.Now())
+	return ds.DropableStore.Delete(key)
+}
+
 func (ds *DropableStoreWithMetrics) meter(refresh time.Duration) {
+	// Create the counters to store current and previous compaction values
+	compactions := make([][]float64, 2)
+	for i := 0; i < 2; i++ {
+		compactions[i] = make([]float64, 4)
+	}
 	// Create storage for iostats.
 	var iostats [2]float64
 
+	// every stats source is stopped independently after its first failure
 	var (
-		errc chan error
-		merr error
+		errc      chan error
+		statsErr  error
+		ioErr     error
+		tablesErr error
 	)
 
 	timer := time.NewTimer(refresh)
 	defer timer.Stop()
 	// Iterate ad infinitum and collect the stats
-	for i := 1; errc == nil && merr == nil; i++ {
-		// Retrieve the database iostats.
-		ioStats, err := ds.Stat("leveldb.iostats")
-		if err != nil {
-			ds.log.Error("Failed to read database iostats", "err", err)
-			merr = err
-			continue
-		}
-		var nRead, nWrite float64
-		parts := strings.Split(ioStats, " ")
-		if len(parts) < 2 {
-			ds.log.Error("Bad syntax of ioStats", "ioStats", ioStats)
-			merr = fmt.Errorf("bad syntax of ioStats %s", ioStats)
-			continue
-		}
-		if n, err := fmt.Sscanf(parts[0], "Read(MB):%f", &nRead); n != 1 || err != nil {
-			ds.log.Error("Bad syntax of read entry", "entry", parts[0])
-			merr = err
-			continue
-		}
-		if n, err := fmt.Sscanf(parts[1], "Write(MB):%f", &nWrite); n != 1 || err != nil {
-			log.Error("Bad syntax of write entry", "entry", parts[1])
-			merr = err
-			continue
+	for i := 1; errc == nil && (statsErr == nil || ioErr == nil || tablesErr == nil); i++ {
+		if statsErr == nil {
+			statsErr = ds.meterCompactions(compactions[i%2], compactions[(i-1)%2])
+			if statsErr != nil {
+				ds.log.Error("Failed to collect database stats", "err", statsErr)
+			}
 		}
-		if ds.diskReadMeter != nil {
-			ds.diskReadMeter.Mark(int64((nRead - iostats[0]) * 1024 * 1024))
+		if ioErr == nil {
+			ioErr = ds.meterIOStats(&iostats)
+			if ioErr != nil {
+				ds.log.Error("Failed to collect database iostats", "err", ioErr)
+			}
 		}
-		if ds.diskWriteMeter != nil {
-			ds.diskWriteMeter.Mark(int64((nWrite - iostats[1]) * 1024 * 1024))
+		if tablesErr == nil && i%tableSizesRefreshRatio == 1 {
+			tablesErr = ds.meterTableSizes()
+			if tablesErr != nil {
+				ds.log.Error("Failed to collect database table sizes", "err", tablesErr)
+			}
 		}
-		iostats[0], iostats[1] = nRead, nWrite
 
 		// Sleep a bit, then repeat the stats collection
 		select {
@@ -126,7 +164,145 @@ func (ds *DropableStoreWithMetrics) meter(refresh time.Duration) {
 	if errc == nil {
 		errc = <-ds.quitChan
 	}
-	errc <- merr
+	for _, err := range []error{statsErr, ioErr, tablesErr} {
+		if err != nil {
+			errc <- err
+			return
+		}
+	}
+	errc <- nil
+}
+
+// meterCompactions updates the size and compaction metrics from the "leveldb.stats" property
+func (ds *DropableStoreWithMetrics) meterCompactions(cur, prev []float64) error {
+	stats, err := ds.Stat("leveldb.stats")
+	if err != nil {
+		return err
+	}
+	// Find the compaction table, skip the header
+	lines := strings.Split(stats, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) != "Compactions" {
+		lines = lines[1:]
+	}
+	if len(lines) <= 3 {
+		return errors.New("compaction table not found")
+	}
+	lines = lines[3:]
+
+	// Iterate over all the table rows, and accumulate the entries
+	for j := 0; j < len(cur); j++ {
+		cur[j] = 0
+	}
+	for _, line := range lines {
+		parts := strings.Split(line, "|")
+		if len(parts) != 6 {
+			break
+		}
+		for idx, counter := range parts[2:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(counter), 64)
+			if err != nil {
+				return fmt.Errorf("compaction entry parsing failed: %v", err)
+			}
+			cur[idx] += value
+		}
+	}
+	// Update all the requested meters
+	ds.diskSizeGauge.Update(int64(cur[0] * 1024 * 1024))
+	ds.compTimeMeter.Mark(int64((cur[1] - prev[1]) * 1000 * 1000 * 1000))
+	ds.compReadMeter.Mark(int64((cur[2] - prev[2]) * 1024 * 1024))
+	ds.compWriteMeter.Mark(int64((cur[3] - prev[3]) * 1024 * 1024))
+	return nil
+}
+
+// meterIOStats updates the disk read/write metrics from the "leveldb.iostats" property
+func (ds *DropableStoreWithMetrics) meterIOStats(iostats *[2]float64) error {
+	ioStats, err := ds.Stat("leveldb.iostats")
+	if err != nil {
+		return err
+	}
+	var nRead, nWrite float64
+	parts := strings.Split(ioStats, " ")
+	if len(parts) < 2 {
+		return fmt.Errorf("bad syntax of ioStats %s", ioStats)
+	}
+	if n, err := fmt.Sscanf(parts[0], "Read(MB):%f", &nRead); n != 1 || err != nil {
+		return fmt.Errorf("bad syntax of read entry %s", parts[0])
+	}
+	if n, err := fmt.Sscanf(parts[1], "Write(MB):%f", &nWrite); n != 1 || err != nil {
+		return fmt.Errorf("bad syntax of write entry %s", parts[1])
+	}
+	ds.diskReadMeter.Mark(int64((nRead - iostats[0]) * 1024 * 1024))
+	ds.diskWriteMeter.Mark(int64((nWrite - iostats[1]) * 1024 * 1024))
+	iostats[0], iostats[1] = nRead, nWrite
+	return nil
+}
+
+// meterTableSizes updates the per-table size gauges from the "leveldb.sstables" property
+func (ds *DropableStoreWithMetrics) meterTableSizes() error {
+	sstables, err := ds.Stat("leveldb.sstables")
+	if err != nil {
+		return err
+	}
+	sizes, err := parseTableSizes(sstables)
+	if err != nil {
+		return err
+	}
+	for table := range sizes {
+		if _, ok := ds.tableSizeGauges[table]; !ok {
+			ds.tableSizeGauges[table] = metrics.GetOrRegisterGauge(ds.metricsPrefix+"/table/"+tableMetricName(table)+"/size", nil)
+		}
+	}
+	for table, gauge := range ds.tableSizeGauges {
+		gauge.Update(sizes[table])
+	}
+	return nil
+}
+
+// parseTableSizes estimates disk sizes of the tables, i.e. of the first key bytes, from the "leveldb.sstables" property.
+// Each sstable is accounted to the table of its first key.
+func parseTableSizes(sstables string) (map[byte]int64, error) {
+	sizes := make(map[byte]int64)
+	for _, line := range strings.Split(sstables, "\n") {
+		if len(line) == 0 || strings.HasPrefix(line, "---") {
+			continue
+		}
+		// num:size["first key" .. "last key"]
+		colon := strings.IndexByte(line, ':')
+		bracket := strings.IndexByte(line, '[')
+		if colon < 0 || bracket < colon || bracket+1 >= len(line) || line[bracket+1] != '"' {
+			return nil, fmt.Errorf("bad syntax of sstable entry %s", line)
+		}
+		size, err := strconv.ParseInt(line[colon+1:bracket], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad syntax of sstable entry %s", line)
+		}
+		quoted := line[bracket+1:]
+		end := 1
+		for ; end < len(quoted) && quoted[end] != '"'; end++ {
+			if quoted[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(quoted) {
+			return nil, fmt.Errorf("bad syntax of sstable entry %s", line)
+		}
+		first, err := strconv.Unquote(quoted[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("bad syntax of sstable entry %s", line)
+		}
+		if len(first) != 0 {
+			sizes[first[0]] += size
+		}
+	}
+	return sizes, nil
+}
+
+// tableMetricName returns the table tag if it's printable, or its hex otherwise
+func tableMetricName(table byte) string {
+	if table >= 'a' && table <= 'z' || table >= 'A' && table <= 'Z' || table >= '0' && table <= '9' {
+		return string(table)
+	}
+	return fmt.Sprintf("0x%02x", table)
 }
 
 func (db *DBProducerWithMetrics) OpenDB(name string) (kvdb.DropableStore, error) {
@@ -140,8 +316,20 @@ func (db *DBProducerWithMetrics) OpenDB(name string) (kvdb.DropableStore, error)
 	}
 	logger := log.New("database", name)
 	dm.log = logger
-	dm.diskReadMeter = metrics.GetOrRegisterMeter("opera/chaindata/"+name+"/disk/read", nil)
-	dm.diskWriteMeter = metrics.GetOrRegisterMeter("opera/chaindata/"+name+"/disk/write", nil)
+	dm.metricsPrefix = "opera/chaindata/" + name
+	dm.diskReadMeter = metrics.GetOrRegisterMeter(dm.metricsPrefix+"/disk/read", nil)
+	dm.diskWriteMeter = metrics.GetOrRegisterMeter(dm.metricsPrefix+"/disk/write", nil)
+	dm.diskSizeGauge = metrics.GetOrRegisterGauge(dm.metricsPrefix+"/disk/size", nil)
+	dm.compTimeMeter = metrics.GetOrRegisterMeter(dm.metricsPrefix+"/compact/time", nil)
+	dm.compReadMeter = metrics.GetOrRegisterMeter(dm.metricsPrefix+"/compact/input", nil)
+	dm.compWriteMeter = metrics.GetOrRegisterMeter(dm.metricsPrefix+"/compact/output", nil)
+	dm.tableSizeGauges = make(map[byte]metrics.Gauge)
+	// timing every operation is expensive
+	if metrics.EnabledExpensive {
+		dm.getTimer = metrics.GetOrRegisterTimer(dm.metricsPrefix+"/get", nil)
+		dm.putTimer = metrics.GetOrRegisterTimer(dm.metricsPrefix+"/put", nil)
+		dm.deleteTimer = metrics.GetOrRegisterTimer(dm.metricsPrefix+"/delete", nil)
+	}
 
 	// Start up the metrics gathering and return
 	go dm.meter(metricsGatheringInterval)
@@ -0,0 +1,31 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTableSizes(t *testing.T) {
+	require := require.New(t)
+
+	sizes, err := parseTableSizes(`--- level 0 ---
+5:1024["e\x00\x01" .. "h\xff"]
+6:2048["e\x02" .. "e\x03"]
+--- level 1 ---
+3:4096["\x00\"q" .. "b"]
+4:512["" .. ""]
+--- level 2 ---
+`)
+	require.NoError(err)
+	require.Equal(map[byte]int64{
+		'e':  1024 + 2048,
+		0x00: 4096,
+	}, sizes)
+
+	_, err = parseTableSizes("5:1024[e .. h]")
+	require.Error(err)
+
+	require.Equal("e", tableMetricName('e'))
+	require.Equal("0x21", tableMetricName('!'))
+}
@@ -0,0 +1,109 @@
+package fakeengine
+
+import (
+	"errors"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/dag"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/Fantom-foundation/lachesis-base/lachesis"
+)
+
+var (
+	ErrNotBootstrapped = errors.New("engine isn't bootstrapped")
+	ErrWrongEpoch      = errors.New("event of a wrong epoch")
+	ErrUnknownParent   = errors.New("unknown parent")
+	ErrWrongFrame      = errors.New("wrong event frame")
+)
+
+// Engine is a fake implementation of lachesis.Consensus, which decides every processed event instantly
+// as an Atropos of a new block. It provides no BFT guarantees and is intended to test and benchmark
+// the layers above consensus (emitter, txpool, RPC) without the aBFT engine.
+type Engine struct {
+	callbacks  *lachesis.ConsensusCallbacks
+	epoch      idx.Epoch
+	validators *pos.Validators
+	frames     map[hash.Event]idx.Frame
+}
+
+var _ lachesis.Consensus = (*Engine)(nil)
+
+// New creates the fake engine at the specified epoch.
+func New(epoch idx.Epoch, validators *pos.Validators) *Engine {
+	p := &Engine{}
+	_ = p.Reset(epoch, validators)
+	return p
+}
+
+// Bootstrap sets the callbacks which are called on every decided block.
+func (p *Engine) Bootstrap(callbacks lachesis.ConsensusCallbacks) error {
+	p.callbacks = &callbacks
+	return nil
+}
+
+// Reset switches epoch state to a new empty epoch.
+func (p *Engine) Reset(epoch idx.Epoch, validators *pos.Validators) error {
+	p.epoch = epoch
+	p.validators = validators
+	p.frames = make(map[hash.Event]idx.Frame)
+	return nil
+}
+
+// Build sets frame of the event as 1 + the highest frame of its parents.
+func (p *Engine) Build(e dag.MutableEvent) error {
+	if e.Epoch() != p.epoch {
+		return ErrWrongEpoch
+	}
+	frame, err := p.calcFrame(e)
+	if err != nil {
+		return err
+	}
+	e.SetFrame(frame)
+	return nil
+}
+
+// Process decides the event as an Atropos of a new block, which consists only of this event.
+func (p *Engine) Process(e dag.Event) error {
+	if p.callbacks == nil {
+		return ErrNotBootstrapped
+	}
+	if e.Epoch() != p.epoch {
+		return ErrWrongEpoch
+	}
+	frame, err := p.calcFrame(e)
+	if err != nil {
+		return err
+	}
+	if frame != e.Frame() {
+		return ErrWrongFrame
+	}
+	p.frames[e.ID()] = frame
+
+	blockCallbacks := p.callbacks.BeginBlock(&lachesis.Block{
+		Atropos: e.ID(),
+	})
+	if blockCallbacks.ApplyEvent != nil {
+		blockCallbacks.ApplyEvent(e)
+	}
+	if blockCallbacks.EndBlock != nil {
+		if newValidators := blockCallbacks.EndBlock(); newValidators != nil {
+			return p.Reset(p.epoch+1, newValidators)
+		}
+	}
+	return nil
+}
+
+func (p *Engine) calcFrame(e dag.Event) (idx.Frame, error) {
+	frame := idx.Frame(1)
+	for _, parent := range e.Parents() {
+		f, ok := p.frames[parent]
+		if !ok {
+			return 0, ErrUnknownParent
+		}
+		if f+1 > frame {
+			frame = f + 1
+		}
+	}
+	return frame, nil
+}
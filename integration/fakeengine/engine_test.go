@@ -0,0 +1,75 @@
+package fakeengine
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/Fantom-foundation/lachesis-base/inter/dag"
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/inter/pos"
+	"github.com/Fantom-foundation/lachesis-base/lachesis"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Fantom-foundation/go-opera/inter"
+)
+
+func TestEngine(t *testing.T) {
+	require := require.New(t)
+
+	validators := pos.ArrayToValidators([]idx.ValidatorID{1, 2}, []pos.Weight{1, 1})
+	engine := New(1, validators)
+
+	var (
+		atroposes hash.Events
+		applied   hash.Events
+		seal      bool
+	)
+	require.NoError(engine.Bootstrap(lachesis.ConsensusCallbacks{
+		BeginBlock: func(block *lachesis.Block) lachesis.BlockCallbacks {
+			atroposes = append(atroposes, block.Atropos)
+			return lachesis.BlockCallbacks{
+				ApplyEvent: func(e dag.Event) {
+					applied = append(applied, e.ID())
+				},
+				EndBlock: func() *pos.Validators {
+					if seal {
+						return validators
+					}
+					return nil
+				},
+			}
+		},
+	}))
+
+	build := func(epoch idx.Epoch, creator idx.ValidatorID, seq idx.Event, parents hash.Events) *inter.EventPayload {
+		me := &inter.MutableEventPayload{}
+		me.SetEpoch(epoch)
+		me.SetCreator(creator)
+		me.SetSeq(seq)
+		me.SetLamport(idx.Lamport(seq))
+		me.SetParents(parents)
+		require.NoError(engine.Build(me))
+		return me.Build()
+	}
+
+	e1 := build(1, 1, 1, nil)
+	require.Equal(idx.Frame(1), e1.Frame())
+	require.NoError(engine.Process(e1))
+	e2 := build(1, 2, 2, hash.Events{e1.ID()})
+	require.Equal(idx.Frame(2), e2.Frame())
+	require.NoError(engine.Process(e2))
+	require.Equal(hash.Events{e1.ID(), e2.ID()}, atroposes)
+	require.Equal(hash.Events{e1.ID(), e2.ID()}, applied)
+
+	// unknown parent
+	me := &inter.MutableEventPayload{}
+	me.SetEpoch(1)
+	me.SetParents(hash.Events{hash.FakeEvent()})
+	require.Equal(ErrUnknownParent, engine.Build(me))
+
+	// epoch sealing
+	seal = true
+	require.NoError(engine.Process(build(1, 1, 3, hash.Events{e2.ID()})))
+	require.Equal(ErrWrongEpoch, engine.Process(e1))
+	require.NoError(engine.Process(build(2, 1, 1, nil)))
+}
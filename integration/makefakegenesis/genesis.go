@@ -73,7 +73,30 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 		})
 	}
 
-	// deploy essential contracts
+	preDeployContracts(builder)
+	builder.SetCurrentEpoch(genesisEpochRecord(rules, epoch, block, FakeGenesisTime))
+
+	var owner common.Address
+	if num != 0 {
+		owner = validators[0].Address
+	}
+
+	blockProc := makegenesis.DefaultBlockProc()
+	genesisTxs := GetGenesisTxs(epoch-2, validators, builder.TotalSupply(), delegations, owner)
+	err := builder.ExecuteGenesisTxs(blockProc, genesisTxs)
+	if err != nil {
+		panic(err)
+	}
+
+	return builder.Build(genesis.Header{
+		GenesisID:   builder.CurrentHash(),
+		NetworkID:   rules.NetworkID,
+		NetworkName: rules.Name,
+	})
+}
+
+// preDeployContracts deploys the essential contracts
+func preDeployContracts(builder *makegenesis.GenesisBuilder) {
 	// pre deploy NetworkInitializer
 	builder.SetCode(netinit.ContractAddress, netinit.GetContractBin())
 	// pre deploy NodeDriver
@@ -84,13 +107,16 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 	builder.SetCode(sfc.ContractAddress, sfc.GetContractBin())
 	// set non-zero code for pre-compiled contracts
 	builder.SetCode(evmwriter.ContractAddress, []byte{0})
+}
 
-	builder.SetCurrentEpoch(ier.LlrIdxFullEpochRecord{
+// genesisEpochRecord returns the record of the epoch preceding the first epoch
+func genesisEpochRecord(rules opera.Rules, epoch idx.Epoch, block idx.Block, start inter.Timestamp) ier.LlrIdxFullEpochRecord {
+	return ier.LlrIdxFullEpochRecord{
 		LlrFullEpochRecord: ier.LlrFullEpochRecord{
 			BlockState: iblockproc.BlockState{
 				LastBlock: iblockproc.BlockCtx{
 					Idx:     block - 1,
-					Time:    FakeGenesisTime,
+					Time:    start,
 					Atropos: hash.Event{},
 				},
 				FinalizedStateRoot:    hash.Hash{},
@@ -104,8 +130,8 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 			},
 			EpochState: iblockproc.EpochState{
 				Epoch:             epoch - 1,
-				EpochStart:        FakeGenesisTime,
-				PrevEpochStart:    FakeGenesisTime - 1,
+				EpochStart:        start,
+				PrevEpochStart:    start - 1,
 				EpochStateRoot:    hash.Zero,
 				Validators:        pos.NewBuilder().Build(),
 				ValidatorStates:   make([]iblockproc.ValidatorEpochState, 0),
@@ -114,25 +140,7 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 			},
 		},
 		Idx: epoch - 1,
-	})
-
-	var owner common.Address
-	if num != 0 {
-		owner = validators[0].Address
 	}
-
-	blockProc := makegenesis.DefaultBlockProc()
-	genesisTxs := GetGenesisTxs(epoch-2, validators, builder.TotalSupply(), delegations, owner)
-	err := builder.ExecuteGenesisTxs(blockProc, genesisTxs)
-	if err != nil {
-		panic(err)
-	}
-
-	return builder.Build(genesis.Header{
-		GenesisID:   builder.CurrentHash(),
-		NetworkID:   rules.NetworkID,
-		NetworkName: rules.Name,
-	})
 }
 
 func txBuilder() func(calldata []byte, addr common.Address) *types.Transaction {
@@ -0,0 +1,144 @@
+package makefakegenesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/Fantom-foundation/lachesis-base/inter/idx"
+	"github.com/Fantom-foundation/lachesis-base/kvdb/memorydb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Fantom-foundation/go-opera/integration/makegenesis"
+	"github.com/Fantom-foundation/go-opera/inter"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+	"github.com/Fantom-foundation/go-opera/opera"
+	"github.com/Fantom-foundation/go-opera/opera/contracts/driver/drivercall"
+	"github.com/Fantom-foundation/go-opera/opera/genesis"
+	"github.com/Fantom-foundation/go-opera/opera/genesis/gpos"
+	"github.com/Fantom-foundation/go-opera/opera/genesisstore"
+)
+
+type (
+	// GenesisJson is a JSON specification of a custom network genesis
+	GenesisJson struct {
+		Rules       opera.Rules
+		Time        inter.Timestamp
+		Accounts    []GenesisAccount `json:",omitempty"`
+		Validators  []GenesisValidator
+		DriverOwner *common.Address `json:",omitempty"`
+	}
+
+	// GenesisAccount is an account pre-allocated in genesis
+	GenesisAccount struct {
+		Address common.Address
+		Balance *big.Int                    `json:",omitempty"`
+		Nonce   uint64                      `json:",omitempty"`
+		Code    hexutil.Bytes               `json:",omitempty"`
+		Storage map[common.Hash]common.Hash `json:",omitempty"`
+	}
+
+	// GenesisValidator is a validator of the first epoch, its stake is self-delegated
+	GenesisValidator struct {
+		ID      idx.ValidatorID
+		Address common.Address
+		PubKey  hexutil.Bytes // secp256k1 public key
+		Stake   *big.Int
+	}
+)
+
+// LoadGenesisJson reads the genesis specification from a JSON file.
+// Omitted rules fields are taken from the fakenet rules.
+func LoadGenesisJson(path string) (*GenesisJson, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	g := &GenesisJson{
+		Rules: opera.FakeNetRules(),
+		Time:  FakeGenesisTime,
+	}
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// ApplyGenesisJson builds the genesis store from the JSON specification.
+// The resulting genesis ID commits to the whole specification.
+func ApplyGenesisJson(g *GenesisJson) (*genesisstore.Store, error) {
+	if len(g.Validators) == 0 {
+		return nil, errors.New("no genesis validators")
+	}
+	builder := makegenesis.NewGenesisBuilder(memorydb.New())
+
+	for _, acc := range g.Accounts {
+		if acc.Balance != nil {
+			builder.AddBalance(acc.Address, acc.Balance)
+		}
+		if len(acc.Code) != 0 {
+			builder.SetCode(acc.Address, acc.Code)
+		}
+		if acc.Nonce != 0 {
+			builder.SetNonce(acc.Address, acc.Nonce)
+		}
+		for key, val := range acc.Storage {
+			builder.SetStorage(acc.Address, key, val)
+		}
+	}
+
+	validators := make(gpos.Validators, 0, len(g.Validators))
+	delegations := make([]drivercall.Delegation, 0, len(g.Validators))
+	seen := make(map[idx.ValidatorID]bool, len(g.Validators))
+	for _, v := range g.Validators {
+		if v.ID == 0 || seen[v.ID] {
+			return nil, fmt.Errorf("invalid or duplicated validator ID %d", v.ID)
+		}
+		seen[v.ID] = true
+		if _, err := crypto.UnmarshalPubkey(v.PubKey); err != nil {
+			return nil, fmt.Errorf("validator %d public key: %v", v.ID, err)
+		}
+		if v.Stake == nil || v.Stake.Sign() <= 0 {
+			return nil, fmt.Errorf("validator %d has no stake", v.ID)
+		}
+		validators = append(validators, gpos.Validator{
+			ID:      v.ID,
+			Address: v.Address,
+			PubKey: validatorpk.PubKey{
+				Raw:  v.PubKey,
+				Type: validatorpk.Types.Secp256k1,
+			},
+			CreationTime: g.Time,
+		})
+		delegations = append(delegations, drivercall.Delegation{
+			Address:            v.Address,
+			ValidatorID:        v.ID,
+			Stake:              v.Stake,
+			LockedStake:        new(big.Int),
+			EarlyUnlockPenalty: new(big.Int),
+			Rewards:            new(big.Int),
+		})
+	}
+
+	preDeployContracts(builder)
+	builder.SetCurrentEpoch(genesisEpochRecord(g.Rules, 2, 1, g.Time))
+
+	owner := validators[0].Address
+	if g.DriverOwner != nil {
+		owner = *g.DriverOwner
+	}
+	genesisTxs := GetGenesisTxs(0, validators, builder.TotalSupply(), delegations, owner)
+	if err := builder.ExecuteGenesisTxs(makegenesis.DefaultBlockProc(), genesisTxs); err != nil {
+		return nil, err
+	}
+
+	return builder.Build(genesis.Header{
+		GenesisID:   builder.CurrentHash(),
+		NetworkID:   g.Rules.NetworkID,
+		NetworkName: g.Rules.Name,
+	}), nil
+}
@@ -0,0 +1,42 @@
+//go:build gofuzz
+// +build gofuzz
+
+package inter
+
+import (
+	"bytes"
+
+	_ "github.com/dvyukov/go-fuzz/go-fuzz-defs"
+)
+
+const (
+	fuzzHot      int = 1  // if the fuzzer should increase priority of the given input during subsequent fuzzing;
+	fuzzCold     int = -1 // if the input must not be added to corpus even if gives new coverage;
+	fuzzNoMatter int = 0  // otherwise.
+)
+
+// FuzzEventPayload checks that every decodable event has exactly one encoding,
+// i.e. the event ID can't be changed by re-encoding of the same event.
+func FuzzEventPayload(data []byte) int {
+	var e EventPayload
+	if err := e.UnmarshalBinary(data); err != nil {
+		return fuzzCold
+	}
+
+	raw, err := e.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(raw, data) {
+		panic("non-canonical event encoding is accepted")
+	}
+
+	var again EventPayload
+	if err := again.UnmarshalBinary(raw); err != nil {
+		panic(err)
+	}
+	if again.ID() != e.ID() || again.HashToSign() != e.HashToSign() {
+		panic("event hashes aren't stable")
+	}
+	return fuzzHot
+}
@@ -84,6 +84,21 @@ func (e *Event) MarshalBinary() ([]byte, error) {
 	return cser.MarshalBinaryAdapter(e.MarshalCSER)
 }
 
+// UnmarshalBinary implements encoding.BinaryUnmarshaller interface.
+func (e *Event) UnmarshalBinary(raw []byte) (err error) {
+	mutE := MutableEventPayload{}
+	err = cser.UnmarshalBinaryAdapter(raw, func(r *cser.Reader) error {
+		return eventUnmarshalCSER(r, &mutE)
+	})
+	if err != nil {
+		return err
+	}
+	eventSer, _ := mutE.immutable().Event.MarshalBinary()
+	locatorHash, baseHash := calcEventHashes(eventSer, &mutE)
+	*e = mutE.build(locatorHash, baseHash, 0).Event
+	return nil
+}
+
 func eventUnmarshalCSER(r *cser.Reader, e *MutableEventPayload) (err error) {
 	// version
 	var version uint8
@@ -101,6 +101,23 @@ func TestEventPayloadSerialization(t *testing.T) {
 		}
 	})
 
+	t.Run("header", func(t *testing.T) {
+		require := require.New(t)
+
+		for name, header0 := range ee {
+			bin, err := header0.Event.MarshalBinary()
+			require.NoError(err, name)
+
+			var header1 Event
+			require.NoError(header1.UnmarshalBinary(bin), name)
+
+			require.EqualValues(header0.extEventData, header1.extEventData, name)
+			require.EqualValues(header0.baseEvent, header1.baseEvent, name)
+			require.EqualValues(header0.ID(), header1.ID(), name)
+			require.EqualValues(header0.HashToSign(), header1.HashToSign(), name)
+		}
+	})
+
 	t.Run("err", func(t *testing.T) {
 		require := require.New(t)
 
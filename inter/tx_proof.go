@@ -0,0 +1,130 @@
+package inter
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/Fantom-foundation/lachesis-base/hash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	ErrTxIndexOutOfRange = errors.New("transaction index is out of range")
+	ErrWrongTxProof      = errors.New("wrong transaction inclusion proof")
+)
+
+// TxInclusionProof proves that a transaction is included into an event's payload.
+// TxHash is a root of the transactions trie (see CalcTxHash), TxHashProof is the trie path
+// to the transaction. MPsHash and VotesHash link TxHash to PayloadHash of version 1 events.
+type TxInclusionProof struct {
+	Index       uint32
+	TxHash      hash.Hash
+	TxHashProof [][]byte
+	MPsHash     hash.Hash
+	VotesHash   hash.Hash
+}
+
+// proofNodes collects trie nodes during proving and serves them during verification
+type proofNodes [][]byte
+
+func (p *proofNodes) Put(key []byte, value []byte) error {
+	*p = append(*p, common.CopyBytes(value))
+	return nil
+}
+
+func (p *proofNodes) Delete(key []byte) error {
+	return errors.New("not supported")
+}
+
+func (p proofNodes) index() proofNodesIndex {
+	index := make(proofNodesIndex, len(p))
+	for _, node := range p {
+		index[string(crypto.Keccak256(node))] = node
+	}
+	return index
+}
+
+type proofNodesIndex map[string][]byte
+
+func (index proofNodesIndex) Has(key []byte) (bool, error) {
+	_, ok := index[string(key)]
+	return ok, nil
+}
+
+func (index proofNodesIndex) Get(key []byte) ([]byte, error) {
+	if node, ok := index[string(key)]; ok {
+		return node, nil
+	}
+	return nil, ErrWrongTxProof
+}
+
+func txTrieKey(i uint32) []byte {
+	return rlp.AppendUint64(nil, uint64(i))
+}
+
+// TxInclusionProof produces a proof that the i-th transaction is part of the event's payload
+func (e *EventPayload) TxInclusionProof(i int) (*TxInclusionProof, error) {
+	txs := e.Txs()
+	if i < 0 || i >= len(txs) {
+		return nil, ErrTxIndexOutOfRange
+	}
+
+	// build the same trie as types.DeriveSha does
+	t, err := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	if err != nil {
+		return nil, err
+	}
+	for j, tx := range txs {
+		txB, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		t.Update(txTrieKey(uint32(j)), txB)
+	}
+
+	proof := &TxInclusionProof{
+		Index:  uint32(i),
+		TxHash: hash.Hash(t.Hash()),
+	}
+	nodes := proofNodes{}
+	if err := t.Prove(txTrieKey(proof.Index), 0, &nodes); err != nil {
+		return nil, err
+	}
+	proof.TxHashProof = nodes
+	if e.Version() > 0 {
+		proof.MPsHash = CalcMisbehaviourProofsHash(e.MisbehaviourProofs())
+		proof.VotesHash = hash.Of(e.EpochVote().Hash().Bytes(), e.BlockVotes().Hash().Bytes())
+	}
+	return proof, nil
+}
+
+// VerifyTxInclusion checks that the transaction is part of the event's payload.
+// Only the event header is required, so the check may be performed without the event body.
+func VerifyTxInclusion(e EventI, tx *types.Transaction, proof *TxInclusionProof) error {
+	if e.Version() == 0 {
+		if proof.TxHash != e.PayloadHash() {
+			return ErrWrongTxProof
+		}
+	} else {
+		payloadHash := hash.Of(hash.Of(proof.TxHash.Bytes(), proof.MPsHash.Bytes()).Bytes(), proof.VotesHash.Bytes())
+		if payloadHash != e.PayloadHash() {
+			return ErrWrongTxProof
+		}
+	}
+
+	txB, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	nodes := proofNodes(proof.TxHashProof)
+	value, err := trie.VerifyProof(common.Hash(proof.TxHash), txTrieKey(proof.Index), nodes.index())
+	if err != nil || !bytes.Equal(value, txB) {
+		return ErrWrongTxProof
+	}
+	return nil
+}
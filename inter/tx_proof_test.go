@@ -0,0 +1,32 @@
+package inter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxInclusionProof(t *testing.T) {
+	require := require.New(t)
+
+	e := FakeEvent(10, 1, 3, true)
+	require.Equal(CalcPayloadHash(e), e.PayloadHash())
+
+	for i, tx := range e.Txs() {
+		proof, err := e.TxInclusionProof(i)
+		require.NoError(err)
+		require.Equal(CalcTxHash(e.Txs()), proof.TxHash)
+		require.NoError(VerifyTxInclusion(&e.Event, tx, proof))
+
+		// wrong transaction
+		other := e.Txs()[(i+1)%len(e.Txs())]
+		require.Equal(ErrWrongTxProof, VerifyTxInclusion(&e.Event, other, proof))
+
+		// wrong index
+		proof.Index = uint32(len(e.Txs()) + i)
+		require.Equal(ErrWrongTxProof, VerifyTxInclusion(&e.Event, tx, proof))
+	}
+
+	_, err := e.TxInclusionProof(len(e.Txs()))
+	require.Equal(ErrTxIndexOutOfRange, err)
+}
@@ -0,0 +1,49 @@
+package rate
+
+import (
+	"sync"
+	"time"
+)
+
+// Bucket is a thread-safe token bucket rate limiter
+type Bucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	mu     sync.Mutex
+}
+
+// NewBucket constructs a full Bucket which is refilled with rate tokens per second up to burst tokens.
+func NewBucket(rate, burst float64) *Bucket {
+	return &Bucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Take consumes n tokens if the bucket isn't empty. Returns false if the bucket is empty.
+// The balance may become negative, so items larger than burst aren't rejected forever.
+func (b *Bucket) Take(n float64) bool {
+	return b.take(n, time.Now())
+}
+
+func (b *Bucket) take(n float64, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	if b.tokens <= 0 {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
@@ -0,0 +1,34 @@
+package rate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBucket(10, 20)
+	now := b.last
+
+	require.True(b.take(20, now))
+	require.False(b.take(1, now))
+
+	// refilled with 10 tokens per second
+	now = now.Add(500 * time.Millisecond)
+	require.True(b.take(5, now))
+	require.False(b.take(1, now))
+
+	// not more than burst
+	now = now.Add(time.Hour)
+	require.True(b.take(30, now))
+	require.False(b.take(1, now))
+
+	// the debt is repaid before next items
+	now = now.Add(time.Second)
+	require.False(b.take(1, now))
+	now = now.Add(time.Second)
+	require.True(b.take(1, now))
+}